@@ -0,0 +1,167 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Format is the container format of an archive
+type Format string
+
+const (
+	Zip   Format = "zip"
+	TarGz Format = "tar.gz"
+)
+
+var AllowedFormats = []string{string(Zip), string(TarGz)}
+
+// ParseFormat validates the name of an archive format
+func ParseFormat(value string) (Format, error) {
+	for _, format := range AllowedFormats {
+		if format == value {
+			return Format(value), nil
+		}
+	}
+	return "", fmt.Errorf("unknown archive format '%s', allowed: %s", value, strings.Join(AllowedFormats, ", "))
+}
+
+// FormatFromFileName returns the format matching the extension of name,
+// e.g. "out.zip" or "out.tar.gz"
+func FormatFromFileName(name string) (Format, error) {
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return Zip, nil
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return TarGz, nil
+	}
+	return "", fmt.Errorf("cannot tell the archive format of '%s', use a .zip or .tar.gz extension", name)
+}
+
+// Extension returns the file extension of the format, without a leading dot
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// ContentType returns the media type of the format
+func (f Format) ContentType() string {
+	if f == Zip {
+		return "application/zip"
+	}
+	return "application/gzip"
+}
+
+// Write archives the directory root of fsys to w. Every entry is stored
+// below root itself, so extracting the archive recreates the directory.
+// File modes, e.g. of executable scripts, are preserved.
+func Write(w io.Writer, format Format, fsys fs.FS, root string) error {
+	switch format {
+	case Zip:
+		return writeZip(w, fsys, root)
+	case TarGz:
+		return writeTarGz(w, fsys, root)
+	}
+	return fmt.Errorf("unsupported archive format '%s'", format)
+}
+
+func writeZip(w io.Writer, fsys fs.FS, root string) error {
+	zw := zip.NewWriter(w)
+
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = entryName(name, d.IsDir())
+		if !d.IsDir() {
+			header.Method = zip.Deflate
+		}
+
+		entry, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return copyFile(entry, fsys, name)
+	})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeTarGz(w io.Writer, fsys fs.FS, root string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = entryName(name, d.IsDir())
+		// The local user and group mean nothing on the receiving machine
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return copyFile(tw, fsys, name)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func copyFile(w io.Writer, fsys fs.FS, name string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// entryName returns the slash separated archive path, directories end in a slash
+func entryName(name string, isDir bool) string {
+	name = path.Clean(name)
+	if isDir {
+		return name + "/"
+	}
+	return name
+}
@@ -0,0 +1,141 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/mahibulhaque/gofast/internal/program"
+)
+
+// entry is what an archive holds for a file or directory
+type entry struct {
+	mode fs.FileMode
+	data string
+}
+
+func readZip(t *testing.T, data []byte) map[string]entry {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := make(map[string]entry)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = entry{mode: f.Mode(), data: string(content)}
+	}
+	return entries
+}
+
+func readTarGz(t *testing.T, data []byte) map[string]entry {
+	t.Helper()
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+
+	entries := make(map[string]entry)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Uid != 0 || header.Gid != 0 || header.Uname != "" || header.Gname != "" {
+			t.Errorf("%s is owned by %d:%d (%s:%s)", header.Name, header.Uid, header.Gid, header.Uname, header.Gname)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[header.Name] = entry{mode: header.FileInfo().Mode(), data: string(content)}
+	}
+	return entries
+}
+
+func TestWrite(t *testing.T) {
+	fsys := program.NewMemFS()
+	for _, dir := range []string{"demo/cmd/api", "other"} {
+		if err := fsys.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := []struct {
+		name string
+		data string
+		perm fs.FileMode
+	}{
+		{"demo/go.mod", "module demo\n", 0o644},
+		{"demo/cmd/api/main.go", "package main\n", 0o644},
+		{"demo/run.sh", "#!/bin/sh\n", 0o755},
+		{"other/skipped.txt", "not archived\n", 0o644},
+	}
+	for _, f := range files {
+		if err := fsys.WriteFile(f.name, []byte(f.data), f.perm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the root directory is archived, under its own name
+	want := map[string]entry{
+		"demo/":                {mode: fs.ModeDir | 0o755},
+		"demo/cmd/":            {mode: fs.ModeDir | 0o755},
+		"demo/cmd/api/":        {mode: fs.ModeDir | 0o755},
+		"demo/cmd/api/main.go": {mode: 0o644, data: "package main\n"},
+		"demo/go.mod":          {mode: 0o644, data: "module demo\n"},
+		"demo/run.sh":          {mode: 0o755, data: "#!/bin/sh\n"},
+	}
+
+	tests := []struct {
+		format Format
+		read   func(t *testing.T, data []byte) map[string]entry
+	}{
+		{Zip, readZip},
+		{TarGz, readTarGz},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, fsys, "demo"); err != nil {
+				t.Fatal(err)
+			}
+
+			got := tt.read(t, buf.Bytes())
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("entries = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, value := range AllowedFormats {
+		if format, err := ParseFormat(value); err != nil || string(format) != value {
+			t.Errorf("ParseFormat(%q) = %q, %v", value, format, err)
+		}
+	}
+
+	_, err := ParseFormat("rar")
+	if want := "unknown archive format 'rar', allowed: zip, tar.gz"; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
@@ -32,7 +32,12 @@ func NonInteractiveCommand(use string, flagSet *pflag.FlagSet) string {
 				if flag.Value.String() == "true" {
 					nonInteractiveCommand = fmt.Sprintf("%s --%s", nonInteractiveCommand, flag.Name)
 				}
-			} else {
+			} else if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				// Repeatable flags such as --set are passed once per value
+				for _, v := range slice.GetSlice() {
+					nonInteractiveCommand = fmt.Sprintf("%s --%s %s", nonInteractiveCommand, flag.Name, v)
+				}
+			} else if flag.Value.String() != "" {
 				nonInteractiveCommand = fmt.Sprintf("%s --%s %s", nonInteractiveCommand, flag.Name, flag.Value.String())
 			}
 		}
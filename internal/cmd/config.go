@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/config"
+	"github.com/mahibulhaque/gofast/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	configCmd.AddCommand(configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the user configuration",
+	Long:  configLong(),
+	Args:  cobra.NoArgs,
+}
+
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List every setting with its effective value and where it came from",
+	Args:    cobra.NoArgs,
+	PreRunE: requireConfig,
+	RunE:    configListCmdRun,
+}
+
+// configLong documents the config file and every setting
+func configLong() string {
+	lines := []string{
+		fmt.Sprintf("The user configuration sets the defaults of every run. It is read from %s in the", config.FileName),
+		"gofast directory of $XDG_CONFIG_HOME or the user config directory, as flat key = \"value\" lines,",
+		fmt.Sprintf("and each setting may be overridden by an environment variable starting with %s.", config.EnvPrefix),
+		"Flags and profiles take precedence over the configuration.",
+		"",
+		"Settings:",
+	}
+	for _, setting := range config.Settings {
+		lines = append(lines, fmt.Sprintf("  %-16s %-24s %s", setting.Key, setting.Env(), setting.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func configListCmdRun(cmd *cobra.Command, args []string) error {
+	theme := styles.CurrentTheme()
+
+	if dir, err := config.Dir(); err == nil {
+		fmt.Println(theme.S().Muted.Render("Config file: " + filepath.Join(dir, config.FileName)))
+		fmt.Println()
+	}
+
+	for _, value := range userConfig.List() {
+		shown := value.Value
+		if shown == "" {
+			shown = "(not set)"
+		}
+		source := value.Source
+		if value.Origin != "" {
+			source += " " + value.Origin
+		}
+		fmt.Println(theme.S().Text.Render(fmt.Sprintf("%-16s %-24s", value.Key, shown)) + theme.S().Muted.Render(source))
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"os"
 	"strings"
@@ -9,16 +12,26 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/term"
+	"github.com/mahibulhaque/gofast/internal/archive"
+	"github.com/mahibulhaque/gofast/internal/doctor"
+	"github.com/mahibulhaque/gofast/internal/executor"
 	"github.com/mahibulhaque/gofast/internal/flags"
 	"github.com/mahibulhaque/gofast/internal/modules"
+	"github.com/mahibulhaque/gofast/internal/profile"
 	"github.com/mahibulhaque/gofast/internal/program"
+	"github.com/mahibulhaque/gofast/internal/registry"
 	"github.com/mahibulhaque/gofast/internal/steps"
+	"github.com/mahibulhaque/gofast/internal/tui/accessible"
+	"github.com/mahibulhaque/gofast/internal/tui/components/form"
 	"github.com/mahibulhaque/gofast/internal/tui/components/list"
 	"github.com/mahibulhaque/gofast/internal/tui/components/logo"
 	"github.com/mahibulhaque/gofast/internal/tui/components/spinner"
 	"github.com/mahibulhaque/gofast/internal/tui/components/textinput"
 	"github.com/mahibulhaque/gofast/internal/tui/styles"
+	"github.com/mahibulhaque/gofast/internal/vars"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func init() {
@@ -29,16 +42,36 @@ func init() {
 	rootCmd.AddCommand(createCmd)
 
 	createCmd.Flags().StringP("name", "n", "", "Name of project to create")
-	createCmd.Flags().VarP(&flagFramework, "framework", "f", fmt.Sprintf("Framework to use. Allowed values: %s", strings.Join(flags.AllowedProjectTypes, ", ")))
-	createCmd.Flags().VarP(&flagDBDriver, "driver", "d", fmt.Sprintf("Database drivers to use. Allowed values: %s", strings.Join(flags.AllowedDBDrivers, ", ")))
+	createCmd.Flags().VarP(registry.FrameworkFlag(&flagFramework), "framework", "f", fmt.Sprintf("Framework to use. Allowed values: %s", strings.Join(registry.FrameworkValues(), ", ")))
+	createCmd.Flags().VarP(registry.DriverFlag(&flagDBDriver), "driver", "d", fmt.Sprintf("Database drivers to use. Allowed values: %s", strings.Join(registry.DriverValues(), ", ")))
 	createCmd.Flags().BoolP("advanced", "a", false, "Get prompts for advanced features")
-	createCmd.Flags().Var(&advancedFeatures, "feature", fmt.Sprintf("Advanced feature to use. Allowed values: %s", strings.Join(flags.AllowedAdvancedFeatures, ", ")))
-	createCmd.Flags().VarP(&flagGit, "git", "g", fmt.Sprintf("Git to use. Allowed values: %s", strings.Join(flags.AllowedGitsOptions, ", ")))
-
-	RegisterStaticCompletions(createCmd, "framework", flags.AllowedProjectTypes)
-	RegisterStaticCompletions(createCmd, "driver", flags.AllowedDBDrivers)
-	RegisterStaticCompletions(createCmd, "feature", flags.AllowedAdvancedFeatures)
-	RegisterStaticCompletions(createCmd, "git", flags.AllowedGitsOptions)
+	createCmd.Flags().Var(registry.FeaturesFlag(&advancedFeatures), "feature", fmt.Sprintf("Advanced feature to use. Allowed values: %s", strings.Join(registry.FeatureValues(), ", ")))
+	createCmd.Flags().VarP(registry.GitFlag(&flagGit), "git", "g", fmt.Sprintf("Git to use. Allowed values: %s", strings.Join(registry.GitValues(), ", ")))
+	createCmd.Flags().Bool("install-frontend", false, "Install the dependencies of the frontend once it is generated, otherwise make run installs them")
+	createCmd.Flags().String("archive", "", "Write the project to a .zip or .tar.gz archive instead of a directory. The files are rendered in memory and no go, gofmt, git or npm command is run")
+	createCmd.Flags().StringArray("set", nil, fmt.Sprintf("Set a template variable as key=value, may be repeated. Allowed keys: %s", strings.Join(vars.Keys(), ", ")))
+	createCmd.Flags().String("vars-file", "", "File of key=value lines setting template variables, overridden by --set")
+	createCmd.Flags().StringP("profile", "p", "", "Profile preselecting the framework, driver, features and git option. Flags given next to it take precedence. Built-in profiles: "+strings.Join(profile.Names(profile.Builtin), ", "))
+	createCmd.Flags().Bool("accessible", false, "Ask every question as a plain numbered prompt on stdin and stdout, without colors, animation or full screen UI. Works with piped input")
+	createCmd.Flags().Bool("non-interactive", false, "Never prompt; fail if a required option is missing. Enabled automatically when stdin is not a terminal")
+
+	RegisterStaticCompletions(createCmd, "framework", registry.FrameworkValues())
+	RegisterStaticCompletions(createCmd, "driver", registry.DriverValues())
+	RegisterStaticCompletions(createCmd, "feature", registry.FeatureValues())
+	RegisterStaticCompletions(createCmd, "git", registry.GitValues())
+	RegisterStaticCompletions(createCmd, "set", vars.Keys())
+
+	// User profiles may change between runs, so they are read when completing
+	err := createCmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		profiles, err := profile.All()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return profile.Names(profiles), cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		log.Printf("warning: could not register completion for --profile: %v", err)
+	}
 }
 
 type Options struct {
@@ -46,6 +79,7 @@ type Options struct {
 	ProjectType *list.Selection
 	DBDriver    *list.Selection
 	Advanced    *list.MultiSelection
+	Vars        *form.Output
 	Workflow    *list.Selection
 	Git         *list.Selection
 }
@@ -53,26 +87,133 @@ type Options struct {
 func createCmdRun(cmd *cobra.Command, args []string) {
 	var err error
 
+	// Accessible prompts are plain lines, the theme only colors the
+	// messages around them
+	flagAccessible, err := cmd.Flags().GetBool("accessible")
+	if err != nil {
+		fatal("failed to retrieve accessible flag")
+	}
+	var prompter *accessible.Prompter
+	if flagAccessible {
+		prompter = accessible.New(os.Stdin, os.Stdout)
+		checkErr(styles.SetDefaultManager().SetTheme(styles.NoColorThemeName))
+	}
+
 	theme := styles.CurrentTheme()
 
 	isInteractive := false
-	flagName := cmd.Flag("name").Value.String()
+	flagName := userConfig.ModulePath(cmd.Flag("name").Value.String())
+	packageManager := flags.PackageManager(userConfig.Get("package_manager"))
 
-	if flagName != "" && !modules.ValidateModuleName(flagName) {
-		err = fmt.Errorf("'%s' is not a valid module name. Please choose a different name", flagName)
-		cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+	if flagName != "" {
+		if err := modules.CheckModuleName(flagName); err != nil {
+			checkErr(textinput.CreateErrorInputModel(err).Err())
+		}
+	}
+
+	installFrontend, _ := cmd.Flags().GetBool("install-frontend")
+
+	// An archive is rendered in memory, so the project directory may exist
+	archivePath := cmd.Flag("archive").Value.String()
+	var archiveFormat archive.Format
+	if archivePath != "" {
+		archiveFormat, err = archive.FormatFromFileName(archivePath)
+		checkErr(err)
 	}
 
 	rootDirName := modules.GetRootDir(flagName)
-	if rootDirName != "" && doesDirectoryExistAndIsNotEmpty(rootDirName) {
+	if archivePath == "" && rootDirName != "" && doesDirectoryExistAndIsNotEmpty(rootDirName) {
 		err = fmt.Errorf("directory '%s' already exists and is not empty. Please choose a different name", rootDirName)
-		cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+		checkErr(textinput.CreateErrorInputModel(err).Err())
+	}
+
+	nonInteractive, err := cmd.Flags().GetBool("non-interactive")
+	if err != nil {
+		fatal("failed to retrieve non-interactive flag")
+	}
+	// Accessible prompts read lines, so they can be answered by a script
+	if !nonInteractive && !flagAccessible && !term.IsTerminal(os.Stdin.Fd()) {
+		nonInteractive = true
+	}
+
+	// The title art is for people, scripts and screen readers get one line
+	if flagAccessible || nonInteractive {
+		fmt.Println("Gofast 0.1.0")
+	} else {
+		fmt.Printf("%s\n", logo.Render("0.1.0", false, logo.ThemeOpts(theme)))
+	}
+
+	profiles, err := profile.All()
+	checkErr(err)
+
+	// A profile is only offered when nothing was chosen with flags or the
+	// user config yet
+	flagProfile := cmd.Flag("profile").Value.String()
+	if flagProfile == "" && !nonInteractive && !cmd.Flag("framework").Changed && !cmd.Flag("driver").Changed &&
+		userConfig.Get("framework") == "" && userConfig.Get("driver") == "" {
+		isInteractive = true
+		selection := &list.Selection{}
+		if flagAccessible {
+			checkErr(prompter.Select(steps.ProfileStep(profiles), selection))
+		} else {
+			// Nothing is generated yet, the project only tracks whether
+			// the user quit the prompt
+			prompt := &program.Project{}
+			tprogram := tea.NewProgram(list.NewSingleSelectFromStep(steps.ProfileStep(profiles), selection, prompt))
+			if _, err := tprogram.Run(); err != nil {
+				checkErr(textinput.CreateErrorInputModel(err).Err())
+			}
+			prompt.ExitCLI(tprogram)
+		}
+
+		flagProfile = selection.Flag
+		if flagProfile != "" {
+			err := cmd.Flag("profile").Value.Set(flagProfile)
+			if err != nil {
+				fatal("failed to set the profile flag value", err)
+			}
+		}
+	}
+
+	var profileVars []string
+	if flagProfile != "" {
+		chosen, err := profile.Lookup(profiles, flagProfile)
+		checkErr(err)
+		checkErr(applyProfile(cmd, chosen))
+		profileVars = chosen.Vars
+	}
+	checkErr(applyConfig(cmd))
+
+	templateVars, err := resolveVars(cmd, profileVars)
+	checkErr(err)
+
+	if nonInteractive {
+		if missing := missingCreateFlags(cmd); len(missing) > 0 {
+			checkErr(fmt.Errorf("cannot prompt for missing options in non-interactive mode, please provide:\n%s", strings.Join(missing, "\n")))
+		}
 	}
 
 	flagFramework := flags.Framework(cmd.Flag("framework").Value.String())
 	flagDBDriver := flags.Database(cmd.Flag("driver").Value.String())
 	flagGit := flags.Git(cmd.Flag("git").Value.String())
 
+	// Missing tools are reported before any prompt for what is already
+	// known, and again once every option is chosen. Only the tools of the
+	// selection are probed, and none for an archive, which runs no command
+	flagSelection := registry.Selection{
+		Framework:       flagFramework,
+		Driver:          flagDBDriver,
+		Features:        selectedFeatures(cmd),
+		Git:             flagGit,
+		PackageManager:  packageManager,
+		SkipCommands:    archivePath != "",
+		InstallFrontend: installFrontend,
+	}
+	if archivePath == "" {
+		checks := doctor.RunFor(cmd.Context(), executor.Default, flagSelection)
+		checkErr(doctor.Problems(checks, flagSelection))
+	}
+
 	options := Options{
 		ProjectName: &textinput.Output{},
 		ProjectType: &list.Selection{},
@@ -80,7 +221,8 @@ func createCmdRun(cmd *cobra.Command, args []string) {
 		Advanced: &list.MultiSelection{
 			Selected: make(map[int]bool),
 		},
-		Git: &list.Selection{},
+		Vars: &form.Output{},
+		Git:  &list.Selection{},
 	}
 
 	project := &program.Project{
@@ -91,15 +233,18 @@ func createCmdRun(cmd *cobra.Command, args []string) {
 		DBDriverMap:     make(map[flags.Database]program.DBDriver),
 		AdvancedOptions: make(map[string]bool),
 		GitOptions:      flagGit,
+		Vars:            templateVars,
+		Author:          userConfig.Get("author"),
+		PackageManager:  packageManager,
+		InstallFrontend: installFrontend,
 	}
 
 	steps := steps.InitSteps(flagFramework, flagDBDriver)
-	fmt.Printf("%s\n", logo.Render("0.1.0", false, logo.DefaultOpts()))
 
 	// Advanced option steps:
 	flagAdvanced, err := cmd.Flags().GetBool("advanced")
 	if err != nil {
-		log.Fatal("failed to retrieve advanced flag")
+		fatal("failed to retrieve advanced flag")
 	}
 
 	if flagAdvanced {
@@ -109,215 +254,617 @@ func createCmdRun(cmd *cobra.Command, args []string) {
 
 	if project.ProjectName == "" {
 		isInteractive = true
-		textInputModel := textinput.NewTextInputModel(options.ProjectName, "What is the name of your project?", project)
-		tprogram := tea.NewProgram(textInputModel)
-		if _, err := tprogram.Run(); err != nil {
-			log.Printf("Name of project contains an error: %v", err)
-			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+		// The name is checked as it is typed, with the module prefix of
+		// the user config applied
+		validate := func(name string) error {
+			return validateProjectName(userConfig.ModulePath(name), archivePath != "")
+		}
+		suggest := func(name string) string {
+			return suggestProjectName(name, archivePath != "")
 		}
 
-		// Check if user wants to exit (Ctrl+C or Esc)
-		if textInputModel.ShouldExit() {
-			project.Exit = true
-			project.ExitCLI(tprogram)
-			return
+		if flagAccessible {
+			err := prompter.Input("What is the name of your project?", options.ProjectName, func(name string) error {
+				if err := validate(name); err != nil {
+					if suggestion := suggest(name); suggestion != "" {
+						return fmt.Errorf("%w. Did you mean '%s'?", err, suggestion)
+					}
+					return err
+				}
+				return nil
+			})
+			checkErr(err)
+		} else {
+			textInputModel := textinput.NewTextInputModel(options.ProjectName, "What is the name of your project?", project).WithValidate(validate, suggest)
+			tprogram := tea.NewProgram(textInputModel)
+			if _, err := tprogram.Run(); err != nil {
+				log.Printf("Name of project contains an error: %v", err)
+				checkErr(textinput.CreateErrorInputModel(err).Err())
+			}
+
+			// Check if user wants to exit (Ctrl+C or Esc)
+			if textInputModel.ShouldExit() {
+				project.Exit = true
+				project.ExitCLI(tprogram)
+				return
+			}
 		}
 
-		if options.ProjectName.Output != "" && !modules.ValidateModuleName(options.ProjectName.Output) {
-			err = fmt.Errorf("'%s' is not a valid module name. Please choose a different name", options.ProjectName.Output)
-			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+		projectName := userConfig.ModulePath(options.ProjectName.Output)
+		if projectName != "" && !modules.ValidateModuleName(projectName) {
+			err = fmt.Errorf("'%s' is not a valid module name. Please choose a different name", projectName)
+			checkErr(textinput.CreateErrorInputModel(err).Err())
 		}
 
-		rootDirName = modules.GetRootDir(options.ProjectName.Output)
+		rootDirName = modules.GetRootDir(projectName)
 
-		if doesDirectoryExistAndIsNotEmpty(rootDirName) {
+		if archivePath == "" && doesDirectoryExistAndIsNotEmpty(rootDirName) {
 			err = fmt.Errorf("directory '%s' already exists and is not empty. Please choose a different name", rootDirName)
-			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+			checkErr(textinput.CreateErrorInputModel(err).Err())
 		}
 
-		project.ExitCLI(tprogram)
-
-		project.ProjectName = options.ProjectName.Output
+		project.ProjectName = projectName
 
 		err := cmd.Flag("name").Value.Set(project.ProjectName)
 
 		if err != nil {
-			log.Fatal("Failed to set the name flag value", err)
+			fatal("Failed to set the name flag value", err)
 		}
+	}
 
-		if project.ProjectType == "" {
-			isInteractive = true
-			step := steps.Steps["framework"]
+	if project.ProjectType == "" {
+		isInteractive = true
+		step := steps.Steps["framework"]
 
+		if flagAccessible {
+			checkErr(prompter.Select(step, options.ProjectType))
+		} else {
 			tprogram := tea.NewProgram(list.NewSingleSelectFromStep(step, options.ProjectType, project))
 
 			if _, err := tprogram.Run(); err != nil {
-				cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+				checkErr(textinput.CreateErrorInputModel(err).Err())
 			}
 
 			project.ExitCLI(tprogram)
+		}
 
-			step.Field = options.ProjectType.Choice
+		step.Field = options.ProjectType.Choice
 
-			project.ProjectType = flags.Framework(strings.ToLower(options.ProjectType.Choice))
-			err := cmd.Flag("framework").Value.Set(project.ProjectType.String())
-			if err != nil {
-				log.Fatal("failed to set the framework flag value", err)
-			}
+		project.ProjectType = flags.Framework(options.ProjectType.Flag)
+		err := cmd.Flag("framework").Value.Set(project.ProjectType.String())
+		if err != nil {
+			fatal("failed to set the framework flag value", err)
 		}
+	}
 
-		if project.DBDriver == "" {
-			isInteractive = true
+	if project.DBDriver == "" {
+		isInteractive = true
 
-			step := steps.Steps["driver"]
+		step := steps.Steps["driver"]
 
-			tprogram = tea.NewProgram(list.NewSingleSelectFromStep(step, options.DBDriver, project))
+		if flagAccessible {
+			checkErr(prompter.Select(step, options.DBDriver))
+		} else {
+			tprogram := tea.NewProgram(list.NewSingleSelectFromStep(step, options.DBDriver, project))
 			if _, err := tprogram.Run(); err != nil {
-				cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+				checkErr(textinput.CreateErrorInputModel(err).Err())
 			}
 			project.ExitCLI(tprogram)
+		}
 
-			/* NOTE: this type casting is always safe since the user interface can only pass strings that can be cast to a flags.Database instance */
-			project.DBDriver = flags.Database(strings.ToLower(options.DBDriver.Choice))
-			err := cmd.Flag("driver").Value.Set(project.DBDriver.String())
-			if err != nil {
-				log.Fatal("failed to set the driver flag value", err)
-			}
+		project.DBDriver = flags.Database(options.DBDriver.Flag)
+		err := cmd.Flag("driver").Value.Set(project.DBDriver.String())
+		if err != nil {
+			fatal("failed to set the driver flag value", err)
 		}
+	}
 
-		if flagAdvanced {
-			featureFlags := cmd.Flag("feature").Value.String()
+	// Features passed with --feature are used as they are, the prompt is
+	// only shown in advanced mode when none were given
+	featureFlags := cmd.Flag("feature").Value.String()
+	if featureFlags != "" {
+		for _, key := range strings.Split(featureFlags, ",") {
+			project.AdvancedOptions[key] = true
+		}
+	} else if flagAdvanced && !nonInteractive {
+		// Without --feature a non-interactive run adds no features
+		isInteractive = true
+		// Features that cannot be used with the chosen framework and
+		// driver are shown disabled, conflicts between features are
+		// reported when confirming
+		selection := registry.Selection{
+			Framework:       project.ProjectType,
+			Driver:          project.DBDriver,
+			PackageManager:  packageManager,
+			SkipCommands:    archivePath != "",
+			InstallFrontend: installFrontend,
+		}
+		step := steps.Steps["advanced"].DisableUnavailable(registry.Unavailable(selection))
+		validate := func(features []string) error {
+			selection.Features = features
+			_, err := registry.Resolve(selection)
+			return err
+		}
 
-			if featureFlags != "" {
-				featureFlagValues := strings.Split(featureFlags, ",")
+		if flagAccessible {
+			checkErr(prompter.MultiSelect(step, options.Advanced, validate))
+		} else {
+			multiSelect := list.NewMultiSelectFromStep(step, options.Advanced, project)
+			multiSelect.SetValidate(validate)
+			tprogram := tea.NewProgram(multiSelect)
 
-				for _, key := range featureFlagValues {
-					project.AdvancedOptions[key] = true
-				}
-			} else {
+			if _, err := tprogram.Run(); err != nil {
+				checkErr(textinput.CreateErrorInputModel(err).Err())
+			}
 
-				isInteractive = true
-				step := steps.Steps["advanced"]
-				tprogram = tea.NewProgram(list.NewMultiSelectFromStep(step, options.Advanced, project))
+			project.ExitCLI(tprogram)
+		}
 
+		// Flags only holds the confirmed items, in list order
+		for _, flag := range options.Advanced.Flags {
+			project.AdvancedOptions[flag] = true
+			err := cmd.Flag("feature").Value.Set(flag)
+			if err != nil {
+				fatal("failed to set the feature flag value ", err)
+			}
+		}
+	}
 
-				if _, err := tprogram.Run(); err != nil {
-					cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
-				}
+	resolution, err := registry.Resolve(registry.Selection{
+		Framework:       project.ProjectType,
+		Driver:          project.DBDriver,
+		Features:        selectedFeatures(cmd),
+		PackageManager:  packageManager,
+		SkipCommands:    archivePath != "",
+		InstallFrontend: installFrontend,
+	})
+	checkErr(err)
+	// Implied features are added again by every run, so they are not
+	// repeated in the non-interactive command
+	for _, feature := range resolution.Features {
+		project.AdvancedOptions[feature] = true
+	}
 
-				project.ExitCLI(tprogram)
+	// Like the features, the variables are only prompted for in advanced
+	// mode when none were given
+	setFlag, _ := cmd.Flags().GetStringArray("set")
+	if flagAdvanced && !nonInteractive && len(setFlag) == 0 && cmd.Flag("vars-file").Value.String() == "" {
+		isInteractive = true
+		if flagAccessible {
+			checkErr(prompter.Form("Customise the generated configuration, press enter to keep a value:", customisableVars(project), options.Vars))
+		} else {
+			tprogram := tea.NewProgram(form.NewFormModel(customisableVars(project), options.Vars, "Customise the generated configuration:", project))
+			if _, err := tprogram.Run(); err != nil {
+				checkErr(textinput.CreateErrorInputModel(err).Err())
+			}
+			project.ExitCLI(tprogram)
+		}
 
-				for key, opt := range options.Advanced.Selected {
-					if opt && key < len(options.Advanced.Choices) {
-						project.AdvancedOptions[strings.ToLower(options.Advanced.Flags[key])] = opt
-						err := cmd.Flag("feature").Value.Set(strings.ToLower(options.Advanced.Flags[key]))
-						if err != nil {
-							log.Fatal("failed to set the feature flag value ", err)
-						}
-					}
-				}
+		err := project.Vars.SetMap(options.Vars.Values)
+		if err != nil {
+			checkErr(textinput.CreateErrorInputModel(err).Err())
+		}
 
-				if err != nil {
-					log.Fatal("Failed to set the advanced option", err)
-				}
-			}
+		// Only the values that differ from the defaults are repeated in
+		// the non-interactive command
+		err = cmd.Flag("set").Value.(pflag.SliceValue).Replace(project.Vars.Changed())
+		if err != nil {
+			fatal("failed to set the set flag value", err)
 		}
-		if project.GitOptions == "" {
-			isInteractive = true
-			step := steps.Steps["git"]
-			tprogram = tea.NewProgram(list.NewSingleSelectFromStep(step, options.Git, project))
+	}
+
+	// There is no repository to initialize inside an archive
+	if project.GitOptions == "" && archivePath != "" {
+		project.GitOptions = flags.Skip
+		err := cmd.Flag("git").Value.Set(project.GitOptions.String())
+		if err != nil {
+			fatal("failed to set the git flag value", err)
+		}
+	}
+
+	if project.GitOptions == "" {
+		isInteractive = true
+		step := steps.Steps["git"]
+		if flagAccessible {
+			checkErr(prompter.Select(step, options.Git))
+		} else {
+			tprogram := tea.NewProgram(list.NewSingleSelectFromStep(step, options.Git, project))
 			if _, err := tprogram.Run(); err != nil {
-				cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+				checkErr(textinput.CreateErrorInputModel(err).Err())
 			}
 			project.ExitCLI(tprogram)
-
-			project.GitOptions = flags.Git(strings.ToLower(options.Git.Choice))
-			err := cmd.Flag("git").Value.Set(project.GitOptions.String())
-			if err != nil {
-				log.Fatal("failed to set the git flag value", err)
-			}
 		}
 
-		currentWorkingDir, err := os.Getwd()
+		project.GitOptions = flags.Git(options.Git.Flag)
+		err := cmd.Flag("git").Value.Set(project.GitOptions.String())
 		if err != nil {
-			log.Printf("could not get current working directory: %v", err)
-			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
+			fatal("failed to set the git flag value", err)
 		}
-		project.AbsolutePath = currentWorkingDir
+	}
 
-		spinner := tea.NewProgram(spinner.NewSpinnerModel())
+	currentWorkingDir, err := os.Getwd()
+	if err != nil {
+		log.Printf("could not get current working directory: %v", err)
+		checkErr(textinput.CreateErrorInputModel(err).Err())
+	}
+	project.AbsolutePath = currentWorkingDir
+
+	var memFS *program.MemFS
+	if archivePath != "" {
+		memFS = program.NewMemFS()
+		project.FS = memFS
+		project.AbsolutePath = ""
+		project.SkipCommands = true
+	}
 
-		wg := sync.WaitGroup{}
+	selection := registry.Selection{
+		Framework:       project.ProjectType,
+		Driver:          project.DBDriver,
+		Features:        resolution.Features,
+		Git:             project.GitOptions,
+		PackageManager:  packageManager,
+		SkipCommands:    archivePath != "",
+		InstallFrontend: installFrontend,
+	}
+	var checks []doctor.Check
+	if archivePath == "" {
+		checks = doctor.RunFor(cmd.Context(), executor.Default, selection)
+	}
+	if err := doctor.Problems(checks, selection); err != nil {
+		checkErr(textinput.CreateErrorInputModel(err).Err())
+	}
+	for _, check := range doctor.Relevant(checks, selection) {
+		fmt.Println(theme.S().Warning.Render(fmt.Sprintf("Warning: %s %s, %s", check.Name, check.Detail, check.Fix)))
+	}
+	for _, note := range resolution.Notes {
+		fmt.Println(theme.S().Muted.Render("Note: " + note))
+	}
 
-		wg.Add(1)
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
 
-		go func() {
-			defer wg.Done()
+	// In verbose mode the output of every command is streamed to the
+	// terminal, so the spinner is replaced by that output. Non-interactive
+	// runs never draw a TUI at all
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	var spinnerOpts []tea.ProgramOption
+	if verbose || nonInteractive || flagAccessible {
+		spinnerOpts = append(spinnerOpts, tea.WithOutput(io.Discard), tea.WithInput(nil), tea.WithoutSignalHandler())
+	}
+	spinner := tea.NewProgram(spinner.NewSpinnerModel(), spinnerOpts...)
+	if flagAccessible {
+		prompter.Message("Generating the project...")
+	}
 
-			if _, err := spinner.Run(); err != nil {
-				cobra.CheckErr(err)
-			}
-		}()
+	// The spinner error is handled here once the spinner stopped, exiting
+	// from its goroutine would cut the files and transcript being written
+	spinnerErr := make(chan error, 1)
+	wg := sync.WaitGroup{}
 
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Println("The program encountered an unexpected issue and had to exit. The error was:", r)
-				if releaseErr := spinner.ReleaseTerminal(); releaseErr != nil {
-					log.Printf("Problem releasing terminal: %v", releaseErr)
-				}
-			}
-		}()
+	wg.Add(1)
 
-		// This calls the templates
-		err = project.CreateMainFile()
-		if err != nil {
+	go func() {
+		defer wg.Done()
+
+		model, err := spinner.Run()
+		spinnerErr <- err
+
+		// The spinner owns the terminal while generating, so Ctrl+C
+		// arrives as a key press rather than a signal
+		if s, ok := model.(interface{ Quitting() bool }); ok && s.Quitting() {
+			cancel()
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("The program encountered an unexpected issue and had to exit. The error was:", r)
 			if releaseErr := spinner.ReleaseTerminal(); releaseErr != nil {
 				log.Printf("Problem releasing terminal: %v", releaseErr)
 			}
-			log.Printf("Problem creating files for project.")
-			cobra.CheckErr(textinput.CreateErrorInputModel(err).Err())
 		}
+	}()
 
-		// Styled next steps header and bullets
-		fmt.Println()
-		rootDir := modules.GetRootDir(project.ProjectName)
+	// This calls the templates
+	err = project.CreateMainFile(ctx)
+
+	rootDir := modules.GetRootDir(project.ProjectName)
+	if err == nil && archivePath != "" {
+		err = writeArchive(archivePath, archiveFormat, memFS, rootDir)
+	}
 
-		tipsContent := lipgloss.JoinVertical(
+	spinner.Quit()
+	wg.Wait()
+
+	if err != nil {
+		log.Printf("Problem creating files for project.")
+		if logFile := cmd.Flag("log-file").Value.String(); logFile != "" {
+			log.Printf("A transcript of every command that was run is available at %s", logFile)
+		}
+		checkErr(textinput.CreateErrorInputModel(err).Err())
+	}
+	checkErr(<-spinnerErr)
+
+	// Styled next steps header and bullets
+	fmt.Println()
+
+	title, text := theme.S().Title, theme.S().Text
+	// The bold title survives the no-color theme, tips read by a screen
+	// reader or written to a pipe stay plain text
+	if flagAccessible || !term.IsTerminal(os.Stdout.Fd()) {
+		title, text = lipgloss.NewStyle(), lipgloss.NewStyle()
+	}
+
+	tipsContent := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title.Render("Next steps:"),
+		text.Render(fmt.Sprintf("- cd %s", rootDir)),
+	)
+	if archivePath != "" {
+		extract := fmt.Sprintf("- unzip %s", archivePath)
+		if archiveFormat == archive.TarGz {
+			extract = fmt.Sprintf("- tar -xzf %s", archivePath)
+		}
+		tipsContent = lipgloss.JoinVertical(
 			lipgloss.Left,
-			theme.S().Title.Render("Next steps:"),
-			theme.S().Text.Render(fmt.Sprintf("- cd %s", rootDir)),
+			title.Render("Next steps:"),
+			text.Render(extract),
+			text.Render(fmt.Sprintf("- cd %s", rootDir)),
+			text.Render("- go mod tidy"),
 		)
+	}
+
+	fmt.Println(tipsContent)
+
+	if project.Frontend() != "" {
+		frontendTips := []string{text.Render("- cd frontend")}
+		if !project.InstallFrontend || project.SkipCommands {
+			frontendTips = append(frontendTips, text.Render(fmt.Sprintf("- %s install", packageManager)))
+		}
+		frontendTips = append(frontendTips, text.Render(fmt.Sprintf("- %s run dev", packageManager)))
+		tipsContent = lipgloss.JoinVertical(lipgloss.Left, frontendTips...)
 
 		fmt.Println(tipsContent)
+	}
+	if isInteractive {
+		nonInteractiveCommand := NonInteractiveCommand(cmd.Use, cmd.NonInheritedFlags())
+		tipsContent = lipgloss.JoinVertical(lipgloss.Left, text.Render("Repeat with the following non-interactive command:"), title.Render(nonInteractiveCommand))
+
+		fmt.Println(tipsContent)
+	}
+}
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a Go project from pre defined templates",
+	Long:  "GoFast is a CLI tool that allows you to focus on the actual Go code, and not the project structure.",
 
-		if project.AdvancedOptions["react"] {
-			tipsContent = lipgloss.JoinVertical(lipgloss.Left, theme.S().Text.Render("- cd frontend"), theme.S().Text.Render("- npm install"), theme.S().Text.Render("- npm run dev"))
+	PreRunE: requireConfig,
+	Run:     createCmdRun,
+}
+
+// writeArchive stores the project directory root of fsys in a new archive at path
+func writeArchive(path string, format archive.Format, fsys fs.FS, root string) error {
+	archiveFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create archive: %w", err)
+	}
 
-			fmt.Println(tipsContent)
+	if err := archive.Write(archiveFile, format, fsys, root); err != nil {
+		archiveFile.Close()
+		os.Remove(path)
+		return fmt.Errorf("could not write archive: %w", err)
+	}
+
+	return archiveFile.Close()
+}
+
+// applyProfile sets the options of p that were not given as flags. The git
+// option is left out for an archive, which has no repository
+func applyProfile(cmd *cobra.Command, p profile.Profile) error {
+	options := []struct {
+		flag  string
+		value string
+	}{
+		{"framework", p.Framework.String()},
+		{"driver", p.Driver.String()},
+	}
+	if cmd.Flag("archive").Value.String() == "" {
+		options = append(options, struct {
+			flag  string
+			value string
+		}{"git", p.Git.String()})
+	}
+
+	for _, option := range options {
+		if option.value == "" || cmd.Flag(option.flag).Changed {
+			continue
 		}
-		if isInteractive {
-			nonInteractiveCommand := NonInteractiveCommand(cmd.Use, cmd.Flags())
-			tipsContent = lipgloss.JoinVertical(lipgloss.Left, theme.S().Text.Render("Repeat with the following non-interactive command:"), theme.S().Title.Render(nonInteractiveCommand))
+		if err := cmd.Flag(option.flag).Value.Set(option.value); err != nil {
+			return fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+	}
 
-			fmt.Println(tipsContent)
+	// Features add up, so the profile features are only used when none
+	// were given
+	if !cmd.Flag("feature").Changed {
+		for _, feature := range p.Features {
+			if err := cmd.Flag("feature").Value.Set(feature); err != nil {
+				return fmt.Errorf("profile %s: %w", p.Name, err)
+			}
 		}
+	}
+
+	return nil
+}
 
-		err = spinner.ReleaseTerminal()
+// selectedFeatures returns the features given with --feature
+func selectedFeatures(cmd *cobra.Command) []string {
+	value := cmd.Flag("feature").Value.String()
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// applyConfig sets the framework, driver and git option of the user config
+// that were neither given as flags nor by a profile
+func applyConfig(cmd *cobra.Command) error {
+	settings := []string{"framework", "driver"}
+	if cmd.Flag("archive").Value.String() == "" {
+		settings = append(settings, "git")
+	}
+
+	for _, setting := range settings {
+		value := userConfig.Get(setting)
+		if value == "" || cmd.Flag(setting).Value.String() != "" {
+			continue
+		}
+		if err := cmd.Flag(setting).Value.Set(value); err != nil {
+			return fmt.Errorf("user config %s: %w", setting, err)
+		}
+	}
+	return nil
+}
+
+// resolveVars applies the profile assignments, the --vars-file and then
+// every --set assignment on top of the defaults
+func resolveVars(cmd *cobra.Command, profileVars []string) (vars.Vars, error) {
+	templateVars := vars.Defaults()
+
+	for _, assignment := range profileVars {
+		if err := templateVars.SetAssignment(assignment); err != nil {
+			return templateVars, err
+		}
+	}
+
+	if path := cmd.Flag("vars-file").Value.String(); path != "" {
+		file, err := os.Open(path)
 		if err != nil {
-			log.Printf("Could not release terminal: %v", err)
-			cobra.CheckErr(err)
+			return templateVars, fmt.Errorf("could not read vars file: %w", err)
 		}
+		defer file.Close()
 
+		if err := templateVars.Load(file); err != nil {
+			return templateVars, fmt.Errorf("invalid vars file %s: %w", path, err)
+		}
 	}
 
+	assignments, err := cmd.Flags().GetStringArray("set")
+	if err != nil {
+		return templateVars, err
+	}
+	for _, assignment := range assignments {
+		if err := templateVars.SetAssignment(assignment); err != nil {
+			return templateVars, err
+		}
+	}
+
+	return templateVars, nil
 }
 
-var createCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create a Go project from pre defined templates",
-	Long:  "GoFast is a CLI tool that allows you to focus on the actual Go code, and not the project structure.",
+// validateProjectName returns why name cannot be used for a new project. The
+// directory may exist when the project is written to an archive
+func validateProjectName(name string, toArchive bool) error {
+	if err := modules.CheckModuleName(name); err != nil {
+		return err
+	}
+	if rootDir := modules.GetRootDir(name); !toArchive && doesDirectoryExistAndIsNotEmpty(rootDir) {
+		return fmt.Errorf("directory '%s' already exists and is not empty", rootDir)
+	}
+	return nil
+}
+
+// suggestProjectName returns a name close to name that passes
+// validateProjectName, numbering it when its directory is taken. It returns
+// "" when there is no such name
+func suggestProjectName(name string, toArchive bool) string {
+	suggestion := modules.SuggestModuleName(name)
+	if suggestion == "" {
+		return ""
+	}
+
+	candidate := suggestion
+	for i := 2; validateProjectName(userConfig.ModulePath(candidate), toArchive) != nil; i++ {
+		if i > 99 {
+			return ""
+		}
+		candidate = fmt.Sprintf("%s-%d", suggestion, i)
+	}
+	if candidate == name {
+		return ""
+	}
+	return candidate
+}
+
+// customisableVars returns a form field for every variable used by the
+// chosen driver and features, prefilled with its current value
+func customisableVars(project *program.Project) []form.Field {
+	used := map[string]bool{
+		"port":    true,
+		"app_env": true,
+	}
+	if project.DBDriver != flags.None && project.DBDriver != flags.Sqlite {
+		used["db_password"] = true
+		if project.DBDriver != flags.Redis {
+			used["db_user"] = true
+		}
+		if project.DBDriver == flags.MySql || project.DBDriver == flags.Postgres {
+			used["db_name"] = true
+		}
+		if project.DBDriver == flags.MySql {
+			used["db_root_password"] = true
+		}
+	}
+	if project.AdvancedOptions[flags.Docker] {
+		used["go_image_tag"] = true
+	}
+	if project.Frontend() != "" {
+		used["vite_port"] = true
+	}
+	if project.AdvancedOptions[flags.Web] {
+		used["web_port"] = true
+	}
+
+	var fields []form.Field
+	for _, def := range vars.Definitions {
+		if !used[def.Key] {
+			continue
+		}
+		value, _ := project.Vars.Get(def.Key)
+		fields = append(fields, form.Field{
+			Key:         def.Key,
+			Label:       def.Key,
+			Description: def.Description,
+			Value:       value,
+			Validate: func(value string) error {
+				v := vars.Defaults()
+				return v.Set(def.Key, value)
+			},
+		})
+	}
+	return fields
+}
+
+// missingCreateFlags returns a description, including the allowed values, of
+// every option that would otherwise have to be prompted for
+func missingCreateFlags(cmd *cobra.Command) []string {
+	var missing []string
+
+	if cmd.Flag("name").Value.String() == "" {
+		missing = append(missing, "  --name       Name of the project, a valid Go module path (e.g. github.com/acme/myproject)")
+	}
+	if cmd.Flag("framework").Value.String() == "" {
+		missing = append(missing, fmt.Sprintf("  --framework  Allowed values: %s", strings.Join(registry.FrameworkValues(), ", ")))
+	}
+	if cmd.Flag("driver").Value.String() == "" {
+		missing = append(missing, fmt.Sprintf("  --driver     Allowed values: %s", strings.Join(registry.DriverValues(), ", ")))
+	}
+	if cmd.Flag("git").Value.String() == "" && cmd.Flag("archive").Value.String() == "" {
+		missing = append(missing, fmt.Sprintf("  --git        Allowed values: %s", strings.Join(registry.GitValues(), ", ")))
+	}
 
-	Run: createCmdRun,
+	return missing
 }
 
 // doesDirectoryExistAndIsNotEmpty checks if the directory exists and is not empty
@@ -326,7 +873,7 @@ func doesDirectoryExistAndIsNotEmpty(name string) bool {
 		dirEntries, err := os.ReadDir(name)
 		if err != nil {
 			log.Printf("could not read directory: %v", err)
-			cobra.CheckErr(textinput.CreateErrorInputModel(err))
+			checkErr(textinput.CreateErrorInputModel(err))
 		}
 		if len(dirEntries) > 0 {
 			return true
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResolveVars(t *testing.T) {
+	varsFile := filepath.Join(t.TempDir(), "vars")
+	if err := os.WriteFile(varsFile, []byte("# shared\nport=3000\ndb_name=shop\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(t.TempDir(), "bad")
+	if err := os.WriteFile(badFile, []byte("port=3000\nport 4000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		profile []string
+		args    []string
+		// err is part of the expected error, empty when the vars resolve
+		err     string
+		changed []string
+	}{
+		{
+			name:    "profile",
+			profile: []string{"port=4000", "app_env=dev"},
+			changed: []string{"port=4000", "app_env=dev"},
+		},
+		{
+			name:    "vars file overrides profile",
+			profile: []string{"port=4000", "app_env=dev"},
+			args:    []string{"--vars-file", varsFile},
+			changed: []string{"port=3000", "app_env=dev", "db_name=shop"},
+		},
+		{
+			name:    "set overrides vars file",
+			args:    []string{"--vars-file", varsFile, "--set", "port=5000", "--set", "db_name=store"},
+			changed: []string{"port=5000", "db_name=store"},
+		},
+		{
+			name: "missing vars file",
+			args: []string{"--vars-file", filepath.Join(t.TempDir(), "missing")},
+			err:  "could not read vars file",
+		},
+		{
+			name: "vars file parse error",
+			args: []string{"--vars-file", badFile},
+			err:  "line 2: 'port 4000' is not a key=value assignment",
+		},
+		{
+			name: "invalid set port",
+			args: []string{"--set", "port=99999"},
+			err:  "invalid value for port",
+		},
+		{
+			name: "unknown set key",
+			args: []string{"--set", "colour=blue"},
+			err:  "unknown template variable 'colour'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("vars-file", "", "")
+			cmd.Flags().StringArray("set", nil, "")
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			templateVars, err := resolveVars(cmd, tt.profile)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("err = %v, want it to contain %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := templateVars.Changed(); !slices.Equal(got, tt.changed) {
+				t.Errorf("changed = %v, want %v", got, tt.changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/doctor"
+	"github.com/mahibulhaque/gofast/internal/executor"
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the tools gofast and the generated projects need are installed",
+	Long: `Doctor checks the go toolchain, gofmt, git and its user identity, npm and node,
+docker and docker compose, and air. For every tool that is missing it lists the
+options of 'gofast create' that depend on it.`,
+	Args:    cobra.NoArgs,
+	PreRunE: requireConfig,
+	RunE:    doctorCmdRun,
+}
+
+func doctorCmdRun(cmd *cobra.Command, args []string) error {
+	theme := styles.CurrentTheme()
+	// The transcript of the last create is kept, it is usually what a
+	// bug report needs next to the doctor output
+	runner := &executor.Runner{Timeout: executor.Default.Timeout}
+	checks := doctor.Run(cmd.Context(), runner, flags.PackageManager(userConfig.Get("package_manager")))
+
+	failed := false
+	for _, check := range checks {
+		fmt.Println(formatCheck(theme, check))
+		if check.Status == doctor.Failed {
+			failed = true
+		}
+	}
+
+	if failed {
+		// The checks already explain the problem, so usage is not repeated
+		cmd.SilenceUsage = true
+		return errors.New("some prerequisites are missing")
+	}
+	return nil
+}
+
+// formatCheck renders a check as a status line, followed by its problem
+// and the options depending on it
+func formatCheck(theme *styles.Theme, check doctor.Check) string {
+	name := check.Name
+	if check.Version != "" {
+		name += " " + check.Version
+	}
+
+	switch check.Status {
+	case doctor.OK:
+		return theme.S().Success.Render("✓ ") + theme.S().Text.Render(name)
+	case doctor.Warning:
+		name = theme.S().Warning.Render("! ") + theme.S().Text.Render(name)
+	default:
+		name = theme.S().Error.Render("✗ ") + theme.S().Text.Render(name)
+	}
+
+	lines := []string{
+		name + theme.S().Muted.Render(": "+check.Detail),
+		theme.S().Muted.Render("    fix: " + check.Fix),
+	}
+	if len(check.NeededBy) > 0 {
+		lines = append(lines, theme.S().Muted.Render("    needed by: "+strings.Join(check.NeededBy, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,26 +1,219 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
+	"github.com/charmbracelet/x/term"
+	"github.com/mahibulhaque/gofast/internal/config"
+	"github.com/mahibulhaque/gofast/internal/executor"
+	"github.com/mahibulhaque/gofast/internal/tui/styles"
 	"github.com/spf13/cobra"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gofast",
 	Short: "A program to scaffold a Golang project using a popular framework",
-	Long: `Gofast is a CLI tool that allows users to spin up a Go project with the corresponding structure seamlessly. 
+	Long: `Gofast is a CLI tool that allows users to spin up a Go project with the corresponding structure seamlessly.
 It also gives the option to integrate with one of the more popular Go frameworks!`,
+	PersistentPreRunE: setupExecutor,
 }
 
+// transcript is the log file every external command is recorded to
+var transcript *lazyFile
+
+// userConfig holds the defaults of the user configuration, loaded by
+// requireConfig before the commands using it. Other commands, such as
+// version, completion and help, keep working with a broken configuration
+var userConfig *config.Config
+
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
+	if err := execute(); err != nil {
 		os.Exit(1)
 	}
 }
 
+// execute runs the root command, its deferred calls are done before Execute
+// exits
+func execute() (err error) {
+	// Cancel running commands on Ctrl+C or SIGTERM instead of leaving
+	// half-finished child processes behind
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// A failed command keeps its transcript too, cobra skips the post run
+	// functions after an error
+	defer func() {
+		if closeErr := closeTranscript(); closeErr != nil {
+			fmt.Fprintln(os.Stderr, "Error:", closeErr)
+			err = errors.Join(err, closeErr)
+		}
+	}()
+
+	return rootCmd.ExecuteContext(ctx)
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Stream the output of every external command while it runs")
+	rootCmd.PersistentFlags().Duration("cmd-timeout", executor.DefaultTimeout, "Maximum duration of a single external command such as 'go get' (0 disables the timeout)")
+	rootCmd.PersistentFlags().String("log-file", defaultLogFile(), "File recording a transcript of every external command, useful for bug reports")
+	rootCmd.PersistentFlags().String("theme", "", "Theme of the terminal UI, by name or as the path of a .json or .toml theme file. See 'gofast themes'")
+
+	// Theme files may be added between runs, so they are read when completing
+	err := rootCmd.RegisterFlagCompletionFunc("theme", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		themes := styles.SetDefaultManager()
+		if dir, err := config.Dir(); err == nil {
+			_ = themes.LoadThemeDir(filepath.Join(dir, "themes"))
+		}
+		return themes.List(), cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		log.Printf("warning: could not register completion for --theme: %v", err)
+	}
+}
+
+// defaultLogFile returns the transcript location inside the user cache
+// directory, falling back to the temporary directory
+func defaultLogFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, ProgramName, "last-run.log")
+}
+
+// requireConfig is the PreRunE of the commands reading the user
+// configuration or the theme
+func requireConfig(cmd *cobra.Command, args []string) error {
+	if err := loadConfig(cmd); err != nil {
+		// The usage does not help to fix the configuration
+		cmd.SilenceUsage = true
+		return err
+	}
+	return nil
+}
+
+// loadConfig loads the user configuration and the theme files, and selects
+// the theme of --theme, NO_COLOR or the user configuration
+func loadConfig(cmd *cobra.Command) error {
+	var err error
+	userConfig, err = config.Load()
+	if err != nil {
+		return err
+	}
+
+	themes := styles.SetDefaultManager()
+	if dir, err := config.Dir(); err == nil {
+		// A broken theme file only fails the commands when it is the chosen one
+		if err := themes.LoadThemeDir(filepath.Join(dir, "themes")); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	theme := userConfig.Get("theme")
+	// An explicit --theme is the only way to get colors with NO_COLOR set or
+	// when the output is not a terminal
+	if flag := cmd.Flag("theme"); flag != nil && flag.Changed {
+		theme = flag.Value.String()
+	} else if os.Getenv("NO_COLOR") != "" || !term.IsTerminal(os.Stdout.Fd()) {
+		theme = styles.NoColorThemeName
+	}
+
+	// A theme may also be given as the path of a theme file
+	if styles.IsThemeFile(theme) {
+		fileTheme, err := themes.LoadThemeFile(theme)
+		if err != nil {
+			return err
+		}
+		themes.Register(fileTheme)
+		theme = fileTheme.ThemeName
+	}
+
+	return themes.SetTheme(theme)
+}
+
+// setupExecutor configures the default command runner from the persistent flags
+func setupExecutor(cmd *cobra.Command, args []string) error {
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration("cmd-timeout")
+	if err != nil {
+		return err
+	}
+
+	executor.Default.Verbose = verbose
+	executor.Default.Timeout = timeout
+	executor.Default.Stdout = cmd.OutOrStdout()
+	executor.Default.Stderr = cmd.ErrOrStderr()
+
+	logFile := cmd.Flag("log-file").Value.String()
+	if logFile != "" {
+		// The file is only created once a command is actually run so that
+		// commands like 'gofast version' keep the previous transcript
+		transcript = &lazyFile{path: logFile}
+		executor.Default.Transcript = transcript
+	}
+
+	return nil
+}
+
+// closeTranscript closes the transcript of the command, if any
+func closeTranscript() error {
+	if transcript == nil {
+		return nil
+	}
+	executor.Default.Transcript = nil
+	return transcript.Close()
+}
+
+// checkErr is cobra.CheckErr closing the transcript first, os.Exit skips
+// the deferred calls of execute
+func checkErr(msg any) {
+	if msg != nil {
+		_ = closeTranscript()
+	}
+	cobra.CheckErr(msg)
+}
+
+// fatal is log.Fatal closing the transcript first
+func fatal(v ...any) {
+	_ = closeTranscript()
+	log.Fatal(v...)
+}
+
+// lazyFile is an io.Writer that creates the file at path on its first write
+type lazyFile struct {
+	path string
+	file *os.File
+	err  error
+}
+
+func (f *lazyFile) Write(b []byte) (int, error) {
+	if f.file == nil && f.err == nil {
+		if f.err = os.MkdirAll(filepath.Dir(f.path), 0o755); f.err == nil {
+			f.file, f.err = os.Create(f.path)
+		}
+	}
+	if f.err != nil {
+		return 0, fmt.Errorf("could not write log file: %w", f.err)
+	}
+	return f.file.Write(b)
+}
+
+func (f *lazyFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mahibulhaque/gofast/internal/executor"
+	"github.com/mahibulhaque/gofast/internal/serve"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", "localhost:8080", "Address to listen on")
+	serveCmd.Flags().Int("max-concurrent", 2, "Number of projects generated at the same time")
+	serveCmd.Flags().Duration("timeout", 10*time.Minute, "Maximum duration of a single project generation")
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a web form and HTTP API that generate projects as archives",
+	Long: `Serve starts a small HTTP service generating projects with the same templates as the create command.
+
+  GET  /              HTML form to pick the options and download the project
+  GET  /api/options   Allowed values of every option as JSON
+  POST /api/generate  Generate a project from JSON options, e.g.
+                      {"name": "github.com/acme/api", "framework": "chi", "driver": "postgres",
+                       "features": ["docker"], "git": "skip", "format": "tar.gz"}
+                      and respond with a zip or tar.gz archive`,
+	RunE: serveCmdRun,
+}
+
+func serveCmdRun(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	maxConcurrent, _ := cmd.Flags().GetInt("max-concurrent")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	// The service uses its own runner so that concurrent generations do not
+	// all end up in the transcript of the default runner
+	cmdTimeout, _ := cmd.Flags().GetDuration("cmd-timeout")
+	handler := serve.NewHandler(serve.Config{
+		MaxConcurrent: maxConcurrent,
+		Timeout:       timeout,
+		Runner:        &executor.Runner{Timeout: cmdTimeout},
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("gofast is serving on http://%s", addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("could not serve: %w", err)
+		}
+		return nil
+	case <-cmd.Context().Done():
+	}
+
+	log.Printf("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/mahibulhaque/gofast/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(themesCmd)
+}
+
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Preview the themes of the terminal UI",
+	Long: `Themes renders a preview of every built-in theme and of the theme files in the
+themes directory of the gofast config directory (e.g. ~/.config/gofast/themes).
+Pick one with --theme or the theme setting of the user config.`,
+	Args:   cobra.NoArgs,
+	PreRun: previewConfig,
+	Run:    themesCmdRun,
+}
+
+// previewConfig loads the user config for the theme files to preview. A
+// broken config is reported but does not prevent previewing the themes
+func previewConfig(cmd *cobra.Command, args []string) {
+	if err := loadConfig(cmd); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+func themesCmdRun(cmd *cobra.Command, args []string) {
+	manager := styles.SetDefaultManager()
+	current := manager.Current()
+
+	// Previews would add the colors NO_COLOR asks to leave out, and escapes
+	// to a pipe
+	if !cmd.Flag("theme").Changed && (os.Getenv("NO_COLOR") != "" || !term.IsTerminal(os.Stdout.Fd())) {
+		fmt.Println("Colors are off, pass --theme to preview the themes in color:")
+		for _, name := range manager.List() {
+			fmt.Println("  " + name)
+		}
+		return
+	}
+
+	for _, name := range manager.List() {
+		theme, _ := manager.Theme(name)
+		fmt.Println(previewTheme(theme, theme == current))
+		fmt.Println()
+	}
+}
+
+// previewTheme renders the name of theme followed by a sample of its styles
+func previewTheme(theme *styles.Theme, current bool) string {
+	s := theme.S()
+
+	name := s.Title.Render(theme.ThemeName)
+	if current {
+		name += s.Muted.Render(" (current)")
+	}
+	variant := "dark"
+	if !theme.IsDark {
+		variant = "light"
+	}
+
+	lines := []string{
+		name + s.Subtle.Render(" "+variant),
+		"  " + s.TextSelected.Bold(true).Render("▶ Selected option") + "  " + s.Text.Bold(true).Render("Option"),
+		"  " + s.Text.Render("Text") + "  " + s.Muted.Render("Muted") + "  " + s.Subtle.Render("Subtle"),
+		"  " + s.Success.Render("✓ success") + "  " + s.Warning.Render("! warning") + "  " + s.Error.Render("✗ error") + "  " + s.Info.Render("info"),
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,262 @@
+// Package config loads the user configuration, the defaults applied to every
+// run. Settings are read from config.toml in the gofast config directory
+// and may be overridden by GOFAST_* environment variables, e.g.
+//
+//	# ~/.config/gofast/config.toml
+//	module_prefix = "github.com/acme/"
+//	framework = "chi"
+//	git = "commit"
+//
+// Only flat key = "value" pairs are supported.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/registry"
+	"github.com/mahibulhaque/gofast/internal/toml"
+	"github.com/mahibulhaque/gofast/internal/tui/styles"
+)
+
+// FileName is the name of the config file inside Dir
+const FileName = "config.toml"
+
+// EnvPrefix starts the name of the environment variable of every setting
+const EnvPrefix = "GOFAST_"
+
+// Sources of a value
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Setting is a configurable default
+type Setting struct {
+	Key         string
+	Default     string
+	Description string
+	validate    func(value string) error
+}
+
+// Env returns the environment variable overriding the setting, e.g.
+// GOFAST_MODULE_PREFIX
+func (s Setting) Env() string {
+	return EnvPrefix + strings.ToUpper(s.Key)
+}
+
+// Settings lists every setting in the order they are shown
+var Settings = []Setting{
+	{
+		Key:         "framework",
+		Description: "Framework used when --framework is not given",
+		validate:    func(value string) error { _, err := registry.ParseFramework(value); return err },
+	},
+	{
+		Key:         "driver",
+		Description: "Database driver used when --driver is not given",
+		validate:    func(value string) error { _, err := registry.ParseDriver(value); return err },
+	},
+	{
+		Key:         "git",
+		Description: "Git option used when --git is not given",
+		validate:    func(value string) error { _, err := registry.ParseGit(value); return err },
+	},
+	{
+		Key:         "module_prefix",
+		Description: "Prepended to project names without a slash, e.g. github.com/acme/",
+		validate:    validateModulePrefix,
+	},
+	{
+		Key:         "author",
+		Description: "Author named in the generated README",
+	},
+	{
+		Key:         "theme",
+		Default:     "charmtone",
+		Description: "Theme of the terminal UI, by name or as the path of a theme file",
+		validate:    validateTheme,
+	},
+	{
+		Key:         "package_manager",
+		Default:     flags.Npm.String(),
+		Description: "Package manager creating and running the frontend",
+		validate:    func(value string) error { var p flags.PackageManager; return p.Set(value) },
+	},
+}
+
+// Lookup returns the setting called key
+func Lookup(key string) (Setting, bool) {
+	for _, setting := range Settings {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Value is the effective value of a setting and where it came from
+type Value struct {
+	Key   string
+	Value string
+	// Source is SourceDefault, SourceFile or SourceEnv
+	Source string
+	// Origin names the file or environment variable the value was read
+	// from, empty for defaults
+	Origin string
+}
+
+// Config holds the effective value of every setting
+type Config struct {
+	values map[string]Value
+}
+
+// Dir returns the gofast config directory, below $XDG_CONFIG_HOME when it
+// is set and the user config directory of the platform otherwise
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, "gofast"), nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find the user config directory: %w", err)
+	}
+	return filepath.Join(dir, "gofast"), nil
+}
+
+// Load reads the config file of Dir and the environment. A missing config
+// directory or file leaves the defaults
+func Load() (*Config, error) {
+	var path string
+	if dir, err := Dir(); err == nil {
+		path = filepath.Join(dir, FileName)
+	}
+	return LoadFrom(path, os.LookupEnv)
+}
+
+// LoadFrom reads the config file at path, which may be empty or missing,
+// and then the environment variables found by lookupEnv
+func LoadFrom(path string, lookupEnv func(key string) (string, bool)) (*Config, error) {
+	c := &Config{values: make(map[string]Value)}
+	for _, setting := range Settings {
+		c.values[setting.Key] = Value{Key: setting.Key, Value: setting.Default, Source: SourceDefault}
+	}
+
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("could not read config: %w", err)
+		}
+		if err == nil {
+			defer file.Close()
+			if err := c.parse(path, file); err != nil {
+				return nil, fmt.Errorf("invalid config %s: %w", path, err)
+			}
+		}
+	}
+
+	for _, setting := range Settings {
+		value, ok := lookupEnv(setting.Env())
+		if !ok {
+			continue
+		}
+		if err := c.set(setting, value, SourceEnv, setting.Env()); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", setting.Env(), err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Config) parse(path string, r io.Reader) error {
+	return toml.Decode(r, func(key string, value toml.Value) error {
+		setting, ok := Lookup(key)
+		if !ok {
+			return fmt.Errorf("unknown key '%s'. Allowed keys: %s", key, strings.Join(Keys(), ", "))
+		}
+		if value.IsArray {
+			return fmt.Errorf("%s: expected a string, not an array", key)
+		}
+		return c.set(setting, value.String, SourceFile, path)
+	})
+}
+
+func (c *Config) set(setting Setting, value string, source string, origin string) error {
+	// An empty value resets the setting, e.g. GOFAST_FRAMEWORK= ignores
+	// the framework of the config file
+	if value != "" && setting.validate != nil {
+		if err := setting.validate(value); err != nil {
+			return fmt.Errorf("%s: %w", setting.Key, err)
+		}
+	}
+	if value == "" {
+		value = setting.Default
+	}
+	c.values[setting.Key] = Value{Key: setting.Key, Value: value, Source: source, Origin: origin}
+	return nil
+}
+
+// Keys returns the key of every setting
+func Keys() []string {
+	keys := make([]string, len(Settings))
+	for i, setting := range Settings {
+		keys[i] = setting.Key
+	}
+	return keys
+}
+
+// Get returns the effective value of key
+func (c *Config) Get(key string) string {
+	return c.values[key].Value
+}
+
+// List returns the effective value of every setting, in Settings order
+func (c *Config) List() []Value {
+	values := make([]Value, len(Settings))
+	for i, setting := range Settings {
+		values[i] = c.values[setting.Key]
+	}
+	return values
+}
+
+// ModulePath prepends the module prefix to a project name without a slash
+func (c *Config) ModulePath(name string) string {
+	prefix := c.Get("module_prefix")
+	if prefix == "" || name == "" || strings.Contains(name, "/") {
+		return name
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + name
+}
+
+func validateModulePrefix(value string) error {
+	if strings.ContainsAny(value, " \t") {
+		return fmt.Errorf("'%s' is not a module path prefix", value)
+	}
+	return nil
+}
+
+// validateTheme accepts a built-in theme, a theme of the themes directory
+// and the path of a valid theme file
+func validateTheme(value string) error {
+	themes := styles.NewManager()
+	if dir, err := Dir(); err == nil {
+		// A broken theme file only matters when it is the chosen one
+		_ = themes.LoadThemeDir(filepath.Join(dir, "themes"))
+	}
+
+	if styles.IsThemeFile(value) {
+		_, err := themes.LoadThemeFile(value)
+		return err
+	}
+	if _, ok := themes.Theme(value); !ok {
+		return fmt.Errorf("theme %s not found. Available themes: %s", value, strings.Join(themes.List(), ", "))
+	}
+	return nil
+}
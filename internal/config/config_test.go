@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes content to a config file in a new directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func env(values map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func TestLoadFrom(t *testing.T) {
+	// Themes are looked up in the config directory
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	path := writeConfig(t, `# defaults
+framework = "chi" # default
+driver = 'postgres'
+module_prefix = "github.com/acme/"
+`)
+
+	c, err := LoadFrom(path, env(map[string]string{
+		"GOFAST_DRIVER": "sqlite",
+		"GOFAST_AUTHOR": "Acme",
+		// An empty variable resets the setting
+		"GOFAST_MODULE_PREFIX": "",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Value{
+		"framework":       {Key: "framework", Value: "chi", Source: SourceFile, Origin: path},
+		"driver":          {Key: "driver", Value: "sqlite", Source: SourceEnv, Origin: "GOFAST_DRIVER"},
+		"git":             {Key: "git", Source: SourceDefault},
+		"module_prefix":   {Key: "module_prefix", Source: SourceEnv, Origin: "GOFAST_MODULE_PREFIX"},
+		"author":          {Key: "author", Value: "Acme", Source: SourceEnv, Origin: "GOFAST_AUTHOR"},
+		"theme":           {Key: "theme", Value: "charmtone", Source: SourceDefault},
+		"package_manager": {Key: "package_manager", Value: "npm", Source: SourceDefault},
+	}
+	for _, value := range c.List() {
+		if value != want[value.Key] {
+			t.Errorf("%s = %+v, want %+v", value.Key, value, want[value.Key])
+		}
+	}
+	if got := c.ModulePath("api"); got != "api" {
+		t.Errorf("module path = %q, want api once the prefix is reset", got)
+	}
+}
+
+func TestLoadFromMissingFile(t *testing.T) {
+	for _, path := range []string{"", filepath.Join(t.TempDir(), FileName)} {
+		c, err := LoadFrom(path, env(nil))
+		if err != nil {
+			t.Fatalf("%q: %v", path, err)
+		}
+		if got := c.Get("package_manager"); got != "npm" {
+			t.Errorf("%q: package_manager = %q, want the default npm", path, got)
+		}
+	}
+}
+
+func TestLoadFromErrors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		// err is part of the expected error
+		err string
+	}{
+		{
+			name:    "unknown key",
+			content: "framework = \"chi\"\nframwork = \"gin\"\n",
+			err:     "line 2: unknown key 'framwork'",
+		},
+		{
+			name:    "invalid value",
+			content: `framework = "rails"`,
+			err:     "line 1: framework: Framework to use. Allowed values:",
+		},
+		{
+			name:    "unterminated string",
+			content: `framework = "chi`,
+			err:     `line 1: framework: unterminated string "chi`,
+		},
+		{
+			name:    "array",
+			content: `framework = ["chi"]`,
+			err:     "line 1: framework: expected a string, not an array",
+		},
+		{
+			name:    "unknown theme",
+			content: `theme = "neon"`,
+			err:     "line 1: theme: theme neon not found",
+		},
+		{
+			name: "invalid environment variable",
+			env:  map[string]string{"GOFAST_GIT": "push"},
+			err:  "invalid GOFAST_GIT: git: Git to use. Allowed values:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.content)
+			_, err := LoadFrom(path, env(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestModulePath(t *testing.T) {
+	c, err := LoadFrom("", env(map[string]string{"GOFAST_MODULE_PREFIX": "github.com/acme"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"api":                "github.com/acme/api",
+		"gitlab.com/foo/api": "gitlab.com/foo/api",
+		"":                   "",
+	}
+	for name, want := range tests {
+		if got := c.ModulePath(name); got != want {
+			t.Errorf("ModulePath(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,405 @@
+// Package doctor checks that the tools gofast and the generated projects
+// rely on are installed, before a generation fails half way through.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mahibulhaque/gofast/internal/executor"
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/gitconfig"
+	"github.com/mahibulhaque/gofast/internal/registry"
+)
+
+// Status is the outcome of a single check
+type Status int
+
+const (
+	OK Status = iota
+	// Warning means the tool is missing or outdated, but only needed to
+	// run the generated project
+	Warning
+	// Failed means generating a project that needs the tool fails
+	Failed
+)
+
+// Check is the result of checking one tool
+type Check struct {
+	Name    string
+	Version string
+	Status  Status
+	// Detail describes the problem, empty when the check passed
+	Detail string
+	// Fix tells the user how to solve the problem
+	Fix string
+	// NeededBy lists the options that depend on the tool, e.g. "--feature react"
+	NeededBy []string
+
+	// blocks reports whether generating sel fails without the tool
+	blocks func(sel registry.Selection) bool
+	// when reports whether sel uses the tool, to generate or to run it
+	when func(sel registry.Selection) bool
+	// probe looks the tool up and fills in the outcome of the check
+	probe func(ctx context.Context, check *Check)
+}
+
+// Blocks reports whether the check failed in a way that makes generating
+// sel fail
+func (c Check) Blocks(sel registry.Selection) bool {
+	return c.Status == Failed && c.blocks != nil && c.blocks(sel)
+}
+
+// commandTimeout bounds every version query, a hanging tool must not hang
+// the doctor
+const commandTimeout = 10 * time.Second
+
+// minGoVersion is the oldest toolchain the templates compile with, they
+// rely on the method and wildcard patterns of net/http
+var minGoVersion = [2]int{1, 22}
+
+// minNodeVersion is the oldest Node.js major version Vite supports
+const minNodeVersion = 20
+
+// lookPath and output find and query the tools, they may be replaced to
+// check another environment
+var (
+	lookPath = exec.LookPath
+	output   = func(ctx context.Context, name string, args ...string) (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+		defer cancel()
+		out, err := exec.CommandContext(ctx, name, args...).Output()
+		return strings.TrimSpace(string(out)), err
+	}
+)
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// Run checks every tool, with packageManager in place of npm when set. The
+// git identity is looked up through runner, like it is during generation
+func Run(ctx context.Context, runner executor.CommandRunner, packageManager flags.PackageManager) []Check {
+	return probe(ctx, all(runner, packageManager), func(Check) bool { return true })
+}
+
+// RunFor only checks the tools that generating sel needs, or that an
+// option of sel uses, so nothing else is looked up
+func RunFor(ctx context.Context, runner executor.CommandRunner, sel registry.Selection) []Check {
+	return probe(ctx, all(runner, sel.PackageManager), func(check Check) bool {
+		return check.blocks != nil && check.blocks(sel) || check.when(sel)
+	})
+}
+
+// all returns every check, not probed yet
+func all(runner executor.CommandRunner, packageManager flags.PackageManager) []Check {
+	checks := []Check{
+		checkGo(),
+		checkTool("gofmt", "formatting the generated code", "it is part of the Go installation, make sure $(go env GOROOT)/bin is in PATH", whenCommandsRun, whenCommandsRun),
+		checkGit(),
+		checkGitIdentity(runner),
+	}
+	checks = append(checks, checkFeatureTools(packageManager)...)
+	checks = append(checks,
+		checkDocker(),
+		checkDockerCompose(),
+		checkTool("air", "live reload with 'make watch'", "run 'go install github.com/air-verse/air@latest'", nil, whenServerIsBuilt),
+	)
+	return checks
+}
+
+// probe runs the probe of every check that wanted accepts
+func probe(ctx context.Context, checks []Check, wanted func(Check) bool) []Check {
+	var probed []Check
+	for _, check := range checks {
+		if !wanted(check) {
+			continue
+		}
+		check.probe(ctx, &check)
+		probed = append(probed, check)
+	}
+	return probed
+}
+
+// Problems returns an error listing every check that makes generating sel
+// fail, or nil
+func Problems(checks []Check, sel registry.Selection) error {
+	var lines []string
+	for _, check := range checks {
+		if check.Blocks(sel) {
+			lines = append(lines, fmt.Sprintf("  %s: %s, %s", check.Name, check.Detail, check.Fix))
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return fmt.Errorf("missing prerequisites, run 'gofast doctor' for details:\n%s", strings.Join(lines, "\n"))
+}
+
+// Relevant returns the checks that did not pass and concern sel, without
+// being needed to generate it
+func Relevant(checks []Check, sel registry.Selection) []Check {
+	var relevant []Check
+	for _, check := range checks {
+		if check.Status != OK && !check.Blocks(sel) && check.when(sel) {
+			relevant = append(relevant, check)
+		}
+	}
+	return relevant
+}
+
+// whenSelected returns a condition matching the selections using any of
+// options, as named in NeededBy
+func whenSelected(options []string) func(sel registry.Selection) bool {
+	return func(sel registry.Selection) bool {
+		for _, option := range options {
+			if selects(sel, option) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// selects reports whether sel uses the option, as named in NeededBy
+func selects(sel registry.Selection, option string) bool {
+	name, value, _ := strings.Cut(option, " ")
+	switch name {
+	case "--feature":
+		for _, feature := range sel.Features {
+			if feature == value {
+				return true
+			}
+		}
+	case "--driver":
+		return sel.Driver.String() == value
+	}
+	return false
+}
+
+func whenCommandsRun(sel registry.Selection) bool {
+	return !sel.SkipCommands
+}
+
+func whenGitIsUsed(sel registry.Selection) bool {
+	return !sel.SkipCommands && sel.Git != "" && sel.Git != flags.Skip
+}
+
+// whenServerIsBuilt matches the projects with a 'make watch' target, every
+// project but a CLI
+func whenServerIsBuilt(sel registry.Selection) bool {
+	return sel.Framework != flags.Cli
+}
+
+func checkGo() Check {
+	return Check{Name: "go", NeededBy: []string{"go mod init", "go get", "go mod tidy"}, blocks: whenCommandsRun, when: whenCommandsRun, probe: probeGo}
+}
+
+func probeGo(ctx context.Context, check *Check) {
+	out, err := output(ctx, "go", "version")
+	if err != nil {
+		check.Status = Failed
+		check.Detail = "not found"
+		check.Fix = "install Go from https://go.dev/dl"
+		return
+	}
+
+	check.Version = parseVersion(out)
+	if !atLeast(check.Version, minGoVersion[0], minGoVersion[1]) {
+		check.Status = Failed
+		check.Detail = fmt.Sprintf("version %s is older than %d.%d", check.Version, minGoVersion[0], minGoVersion[1])
+		check.Fix = "update Go from https://go.dev/dl"
+	}
+}
+
+func checkGit() Check {
+	return Check{Name: "git", NeededBy: []string{"--git commit", "--git stage"}, blocks: whenGitIsUsed, when: whenGitIsUsed, probe: probeGit}
+}
+
+func probeGit(ctx context.Context, check *Check) {
+	out, err := output(ctx, "git", "--version")
+	if err != nil {
+		check.Status = Failed
+		check.Detail = "not found"
+		check.Fix = "install git from https://git-scm.com or use --git skip"
+		return
+	}
+	check.Version = parseVersion(out)
+}
+
+func checkGitIdentity(runner executor.CommandRunner) Check {
+	return Check{Name: "git identity", NeededBy: []string{"--git commit", "--git stage"}, blocks: whenGitIsUsed, when: whenGitIsUsed, probe: func(ctx context.Context, check *Check) {
+		probeGitIdentity(ctx, runner, check)
+	}}
+}
+
+func probeGitIdentity(ctx context.Context, runner executor.CommandRunner, check *Check) {
+	if _, err := lookPath("git"); err != nil {
+		check.Status = Failed
+		check.Detail = "git is not installed"
+		check.Fix = "install git first"
+		return
+	}
+
+	var unset []string
+	for _, key := range []string{"user.name", "user.email"} {
+		set, err := gitconfig.CheckConfig(ctx, runner, key)
+		if err != nil {
+			check.Status = Failed
+			check.Detail = fmt.Sprintf("could not read %s: %v", key, err)
+			check.Fix = "check your git configuration"
+			return
+		}
+		if !set {
+			unset = append(unset, key)
+		}
+	}
+	if len(unset) > 0 {
+		check.Status = Failed
+		check.Detail = fmt.Sprintf("%s not set", strings.Join(unset, " and "))
+		check.Fix = fmt.Sprintf("run 'git config --global %s <value>' for each of them", strings.Join(unset, "/"))
+	}
+}
+
+// checkFeatureTools checks the tools the registry features declare, and
+// node along with the package managers running on it
+func checkFeatureTools(packageManager flags.PackageManager) []Check {
+	sel := registry.Selection{PackageManager: packageManager}
+	neededBy := make(map[string][]string)
+	var tools []registry.Tool
+	for _, feature := range registry.Features {
+		for _, tool := range feature.Tools {
+			tool = sel.ToolFor(tool)
+			if _, ok := neededBy[tool.Name]; !ok {
+				tools = append(tools, tool)
+			}
+			neededBy[tool.Name] = append(neededBy[tool.Name], "--feature "+feature.Value)
+		}
+	}
+
+	var checks []Check
+	for _, tool := range tools {
+		features := neededBy[tool.Name]
+		blocks := func(sel registry.Selection) bool {
+			for _, option := range features {
+				feature, _ := registry.LookupFeature(strings.TrimPrefix(option, "--feature "))
+				if selects(sel, option) && sel.NeedsTools(feature) {
+					return true
+				}
+			}
+			return false
+		}
+
+		checks = append(checks, Check{Name: tool.Name, NeededBy: features, blocks: blocks, when: whenSelected(features), probe: func(ctx context.Context, check *Check) {
+			out, err := output(ctx, tool.Name, "--version")
+			if err != nil {
+				check.Status = Failed
+				check.Detail = "not found"
+				check.Fix = tool.Install
+				return
+			}
+			check.Version = parseVersion(out)
+		}})
+
+		if tool.Name == flags.Npm.String() || tool.Name == flags.Pnpm.String() || tool.Name == flags.Yarn.String() {
+			checks = append(checks, checkNode(features))
+		}
+	}
+	return checks
+}
+
+func checkNode(neededBy []string) Check {
+	return Check{Name: "node", NeededBy: neededBy, when: whenSelected(neededBy), probe: probeNode}
+}
+
+func probeNode(ctx context.Context, check *Check) {
+	out, err := output(ctx, "node", "--version")
+	if err != nil {
+		check.Status = Warning
+		check.Detail = "not found"
+		check.Fix = "install Node.js from https://nodejs.org"
+		return
+	}
+
+	check.Version = parseVersion(out)
+	if !atLeast(check.Version, minNodeVersion, 0) {
+		check.Status = Warning
+		check.Detail = fmt.Sprintf("version %s is older than %d, the Vite frontend may not start", check.Version, minNodeVersion)
+		check.Fix = "update Node.js from https://nodejs.org"
+	}
+}
+
+// containerOptions lists the options whose projects are run with docker
+func containerOptions() []string {
+	options := []string{"--feature " + flags.Docker}
+	for _, driver := range registry.Drivers {
+		if driver.Docker != nil {
+			options = append(options, "--driver "+driver.Value.String())
+		}
+	}
+	return options
+}
+
+func checkDocker() Check {
+	return Check{Name: "docker", NeededBy: containerOptions(), when: whenSelected(containerOptions()), probe: probeDocker}
+}
+
+func probeDocker(ctx context.Context, check *Check) {
+	out, err := output(ctx, "docker", "--version")
+	if err != nil {
+		check.Status = Warning
+		check.Detail = "not found, it is needed to run the database and app containers"
+		check.Fix = "install Docker from https://docs.docker.com/get-docker"
+		return
+	}
+	check.Version = parseVersion(out)
+}
+
+func checkDockerCompose() Check {
+	return Check{Name: "docker compose", NeededBy: containerOptions(), when: whenSelected(containerOptions()), probe: probeDockerCompose}
+}
+
+func probeDockerCompose(ctx context.Context, check *Check) {
+	out, err := output(ctx, "docker", "compose", "version")
+	if err != nil {
+		check.Status = Warning
+		check.Detail = "not found, 'make docker-run' uses it to start docker-compose.yml"
+		check.Fix = "install the Docker Compose plugin from https://docs.docker.com/compose/install"
+		return
+	}
+	check.Version = parseVersion(out)
+}
+
+// checkTool only checks that name is in PATH, for tools without a version flag
+func checkTool(name string, usedFor string, fix string, blocks func(registry.Selection) bool, when func(registry.Selection) bool) Check {
+	return Check{Name: name, NeededBy: []string{usedFor}, blocks: blocks, when: when, probe: func(ctx context.Context, check *Check) {
+		if _, err := lookPath(name); err != nil {
+			check.Status = Warning
+			if blocks != nil {
+				check.Status = Failed
+			}
+			check.Detail = "not found"
+			check.Fix = fix
+		}
+	}}
+}
+
+// parseVersion returns the first version number in out, e.g. 1.25.0 of
+// "go version go1.25.0 linux/amd64"
+func parseVersion(out string) string {
+	return versionPattern.FindString(out)
+}
+
+func atLeast(version string, major int, minor int) bool {
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		// An unknown version format is given the benefit of the doubt
+		return true
+	}
+	gotMajor, _ := strconv.Atoi(match[1])
+	gotMinor, _ := strconv.Atoi(match[2])
+	return gotMajor > major || gotMajor == major && gotMinor >= minor
+}
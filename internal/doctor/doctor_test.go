@@ -0,0 +1,256 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/registry"
+)
+
+// installed makes the checks find only the given tools, by the version they
+// print. It returns the tools that were looked up
+func installed(t *testing.T, tools map[string]string) *[]string {
+	t.Helper()
+	var queried []string
+
+	oldLookPath, oldOutput := lookPath, output
+	t.Cleanup(func() { lookPath, output = oldLookPath, oldOutput })
+
+	lookPath = func(name string) (string, error) {
+		queried = append(queried, name)
+		if _, ok := tools[name]; !ok {
+			return "", exec.ErrNotFound
+		}
+		return "/usr/bin/" + name, nil
+	}
+	output = func(_ context.Context, name string, args ...string) (string, error) {
+		if len(args) > 0 && args[0] == "compose" {
+			name += " compose"
+		}
+		queried = append(queried, name)
+		version, ok := tools[name]
+		if !ok {
+			return "", exec.ErrNotFound
+		}
+		return version, nil
+	}
+	return &queried
+}
+
+// identityRunner answers every git config lookup as set
+type identityRunner struct{}
+
+func (identityRunner) Run(context.Context, string, []string, string) error { return nil }
+
+var everything = map[string]string{
+	"go":             "go version go1.25.0 linux/amd64",
+	"gofmt":          "",
+	"git":            "git version 2.43.0",
+	"npm":            "10.8.1",
+	"pnpm":           "9.12.0",
+	"node":           "v22.9.0",
+	"docker":         "Docker version 27.3.1, build ce12230",
+	"docker compose": "Docker Compose version v2.29.7",
+	"air":            "",
+}
+
+func names(checks []Check) []string {
+	var names []string
+	for _, check := range checks {
+		names = append(names, check.Name)
+	}
+	return names
+}
+
+func TestRunFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		sel    registry.Selection
+		checks []string
+	}{
+		{
+			name:   "server",
+			sel:    registry.Selection{Framework: flags.Chi, Driver: flags.None, Git: flags.Skip},
+			checks: []string{"go", "gofmt", "air"},
+		},
+		{
+			name:   "cli",
+			sel:    registry.Selection{Framework: flags.Cli, Driver: flags.None, Git: flags.Skip},
+			checks: []string{"go", "gofmt"},
+		},
+		{
+			name:   "git and containers",
+			sel:    registry.Selection{Framework: flags.Gin, Driver: flags.Postgres, Git: flags.Commit},
+			checks: []string{"go", "gofmt", "git", "git identity", "docker", "docker compose", "air"},
+		},
+		{
+			name:   "frontend with pnpm",
+			sel:    registry.Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.React}, PackageManager: flags.Pnpm},
+			checks: []string{"go", "gofmt", "pnpm", "node", "air"},
+		},
+		{
+			name:   "commands skipped",
+			sel:    registry.Selection{Framework: flags.Chi, Driver: flags.None, Git: flags.Commit, SkipCommands: true},
+			checks: []string{"air"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queried := installed(t, everything)
+
+			checks := RunFor(context.Background(), identityRunner{}, tt.sel)
+			if got := names(checks); !reflect.DeepEqual(got, tt.checks) {
+				t.Errorf("checks = %v, want %v", got, tt.checks)
+			}
+			for _, check := range checks {
+				if check.Status != OK {
+					t.Errorf("%s: status = %d, want OK: %s", check.Name, check.Status, check.Detail)
+				}
+			}
+
+			// Only the tools of the returned checks are looked up, the git
+			// identity check looks for git before asking the runner
+			for _, name := range *queried {
+				if !slices.Contains(tt.checks, name) {
+					t.Errorf("looked up %s, which %v does not use", name, tt.sel)
+				}
+			}
+		})
+	}
+}
+
+func TestRunOutdated(t *testing.T) {
+	tools := map[string]string{}
+	for name, version := range everything {
+		tools[name] = version
+	}
+	tools["go"] = "go version go1.21.13 linux/amd64"
+	tools["node"] = "v18.20.4"
+	installed(t, tools)
+
+	checks := Run(context.Background(), identityRunner{}, flags.Npm)
+	status := make(map[string]Check)
+	for _, check := range checks {
+		status[check.Name] = check
+	}
+
+	if check := status["go"]; check.Status != Failed || check.Version != "1.21.13" {
+		t.Errorf("go = %+v, want a failed 1.21.13", check)
+	}
+	if check := status["node"]; check.Status != Warning || check.Version != "18.20.4" {
+		t.Errorf("node = %+v, want an outdated 18.20.4", check)
+	}
+	if check := status["docker"]; check.Status != OK || check.Version != "27.3.1" {
+		t.Errorf("docker = %+v, want 27.3.1", check)
+	}
+}
+
+func TestProblemsAndRelevant(t *testing.T) {
+	tools := map[string]string{}
+	for name, version := range everything {
+		tools[name] = version
+	}
+	for _, missing := range []string{"gofmt", "pnpm", "docker", "docker compose", "air"} {
+		delete(tools, missing)
+	}
+
+	tests := []struct {
+		name     string
+		sel      registry.Selection
+		problems []string
+		relevant []string
+	}{
+		{
+			name:     "server with docker and a frontend",
+			sel:      registry.Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.React, flags.Docker}, PackageManager: flags.Pnpm},
+			problems: []string{"gofmt"},
+			relevant: []string{"pnpm", "docker", "docker compose", "air"},
+		},
+		{
+			name:     "frontend installed",
+			sel:      registry.Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.React}, PackageManager: flags.Pnpm, InstallFrontend: true},
+			problems: []string{"gofmt", "pnpm"},
+			relevant: []string{"air"},
+		},
+		{
+			name:     "cli",
+			sel:      registry.Selection{Framework: flags.Cli, Driver: flags.None},
+			problems: []string{"gofmt"},
+		},
+		{
+			name:     "commands skipped",
+			sel:      registry.Selection{Framework: flags.Echo, Driver: flags.Mongo, SkipCommands: true},
+			relevant: []string{"docker", "docker compose", "air"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installed(t, tools)
+			checks := RunFor(context.Background(), identityRunner{}, tt.sel)
+
+			err := Problems(checks, tt.sel)
+			if len(tt.problems) == 0 && err != nil {
+				t.Errorf("problems = %v, want nil", err)
+			}
+			if len(tt.problems) > 0 {
+				if err == nil {
+					t.Fatalf("problems = nil, want %v", tt.problems)
+				}
+				lines := strings.Split(err.Error(), "\n")[1:]
+				if len(lines) != len(tt.problems) {
+					t.Errorf("problems = %q, want %v", lines, tt.problems)
+				}
+				for i, name := range tt.problems {
+					if i < len(lines) && !strings.HasPrefix(lines[i], "  "+name+": ") {
+						t.Errorf("problem %d = %q, want %s", i, lines[i], name)
+					}
+				}
+			}
+
+			if got := names(Relevant(checks, tt.sel)); !reflect.DeepEqual(got, tt.relevant) {
+				t.Errorf("relevant = %v, want %v", got, tt.relevant)
+			}
+		})
+	}
+}
+
+func TestGitIdentity(t *testing.T) {
+	installed(t, everything)
+	sel := registry.Selection{Framework: flags.Chi, Driver: flags.None, Git: flags.Commit}
+
+	unset := runnerFunc(func(_ context.Context, _ string, args []string, _ string) error {
+		if args[len(args)-1] == "user.email" {
+			// git config --get exits with 1 for a missing key
+			return exec.Command("sh", "-c", "exit 1").Run()
+		}
+		return nil
+	})
+	checks := RunFor(context.Background(), unset, sel)
+
+	err := Problems(checks, sel)
+	if err == nil || !strings.Contains(err.Error(), "git identity: user.email not set") {
+		t.Errorf("problems = %v, want user.email not set", err)
+	}
+
+	failing := runnerFunc(func(context.Context, string, []string, string) error {
+		return errors.New("permission denied")
+	})
+	checks = RunFor(context.Background(), failing, sel)
+	if err := Problems(checks, sel); err == nil || !strings.Contains(err.Error(), "could not read user.name") {
+		t.Errorf("problems = %v, want a read error", err)
+	}
+}
+
+type runnerFunc func(ctx context.Context, name string, args []string, dir string) error
+
+func (f runnerFunc) Run(ctx context.Context, name string, args []string, dir string) error {
+	return f(ctx, name, args, dir)
+}
@@ -2,19 +2,139 @@ package executor
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strings"
+	"sync"
+	"time"
 )
 
+// DefaultTimeout bounds how long a single external command may run before
+// it is killed. Commands like "go get" can otherwise hang forever behind a
+// misconfigured proxy.
+const DefaultTimeout = 5 * time.Minute
+
+// CommandRunner runs a single external command in dir.
+type CommandRunner interface {
+	Run(ctx context.Context, name string, args []string, dir string) error
+}
+
+// Runner executes external commands. The zero value runs commands without a
+// timeout, keeps their output buffered and records no transcript.
+type Runner struct {
+	// Timeout is applied to every command. Zero disables the timeout.
+	Timeout time.Duration
+
+	// Verbose streams the output of every command to Stdout and Stderr
+	// while it runs instead of only surfacing stderr on failure.
+	Verbose bool
+	Stdout  io.Writer
+	Stderr  io.Writer
+
+	// Transcript, when set, receives a record of every command that was
+	// run together with its output, duration and result.
+	Transcript io.Writer
+
+	mu sync.Mutex
+}
+
+// Default is the Runner used by ExecuteCmd and ExecuteCmdContext.
+var Default = &Runner{Timeout: DefaultTimeout}
+
+// ExecuteCmd runs the named command in dir using the Default runner.
 func ExecuteCmd(name string, args []string, dir string) error {
-	command := exec.Command(name, args...)
+	return Default.Run(context.Background(), name, args, dir)
+}
+
+// ExecuteCmdContext runs the named command in dir using the Default runner.
+// The command is killed when ctx is cancelled.
+func ExecuteCmdContext(ctx context.Context, name string, args []string, dir string) error {
+	return Default.Run(ctx, name, args, dir)
+}
+
+// Run executes the named command in dir. The command is killed when ctx is
+// cancelled or the runner's Timeout elapses.
+func (r *Runner) Run(ctx context.Context, name string, args []string, dir string) error {
+	// The parent tells whether the caller stopped the command, or the
+	// runner's own Timeout did
+	parent := ctx
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	command := exec.CommandContext(ctx, name, args...)
 	command.Dir = dir
+	// Give the process a moment to exit after being killed before
+	// abandoning its output pipes.
+	command.WaitDelay = time.Second
+
 	var out bytes.Buffer
 	var stdErr bytes.Buffer
 	command.Stdout = &out
 	command.Stderr = &stdErr
-	if err := command.Run(); err != nil {
-		return fmt.Errorf("%v\n%v", err, stdErr.String())
+	if r.Verbose {
+		command.Stdout = io.MultiWriter(&out, writerOr(r.Stdout, os.Stdout))
+		command.Stderr = io.MultiWriter(&stdErr, writerOr(r.Stderr, os.Stderr))
+	}
+
+	start := time.Now()
+	err := command.Run()
+	r.record(name, args, dir, out.Bytes(), stdErr.Bytes(), time.Since(start), err)
+
+	if err != nil {
+		switch {
+		case errors.Is(parent.Err(), context.DeadlineExceeded):
+			return fmt.Errorf("%s timed out: %w", commandLine(name, args), parent.Err())
+		case errors.Is(parent.Err(), context.Canceled):
+			return fmt.Errorf("%s was cancelled: %w", commandLine(name, args), parent.Err())
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return fmt.Errorf("%s timed out after %s: %w", commandLine(name, args), r.Timeout, ctx.Err())
+		}
+		return fmt.Errorf("%w\n%v", err, stdErr.String())
 	}
 	return nil
 }
+
+// record appends a single command to the transcript, if one is configured.
+func (r *Runner) record(name string, args []string, dir string, stdout, stderr []byte, elapsed time.Duration, runErr error) {
+	if r.Transcript == nil {
+		return
+	}
+
+	result := "ok"
+	if runErr != nil {
+		result = runErr.Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] $ %s\n", time.Now().Format(time.RFC3339), commandLine(name, args))
+	fmt.Fprintf(&b, "dir: %s\n", dir)
+	if len(stdout) > 0 {
+		fmt.Fprintf(&b, "--- stdout ---\n%s\n", bytes.TrimRight(stdout, "\n"))
+	}
+	if len(stderr) > 0 {
+		fmt.Fprintf(&b, "--- stderr ---\n%s\n", bytes.TrimRight(stderr, "\n"))
+	}
+	fmt.Fprintf(&b, "result: %s (%s)\n\n", result, elapsed.Round(time.Millisecond))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	io.WriteString(r.Transcript, b.String())
+}
+
+func commandLine(name string, args []string) string {
+	return strings.TrimSpace(name + " " + strings.Join(args, " "))
+}
+
+func writerOr(w io.Writer, fallback io.Writer) io.Writer {
+	if w == nil {
+		return fallback
+	}
+	return w
+}
@@ -1,9 +1,6 @@
 package flags
 
-import (
-	"fmt"
-	"strings"
-)
+import "strings"
 
 type AdvancedFeatures []string
 
@@ -11,11 +8,18 @@ const (
 	GoProjectWorkflow string = "githubaction"
 	Websocket         string = "websocket"
 	React             string = "react"
+	Vue               string = "vue"
+	Svelte            string = "svelte"
+	Solid             string = "solid"
 	Docker            string = "docker"
+	Connect           string = "connect"
+	GraphQL           string = "graphql"
+	Worker            string = "worker"
+	Web               string = "web"
+	Embed             string = "embed"
+	Htmx              string = "htmx"
 )
 
-var AllowedAdvancedFeatures = []string{string(React), string(GoProjectWorkflow), string(Websocket), string(Docker)}
-
 func (f AdvancedFeatures) String() string {
 	return strings.Join(f, ",")
 }
@@ -24,13 +28,9 @@ func (f *AdvancedFeatures) Type() string {
 	return "AdvancedFeatures"
 }
 
+// Set adds value unchecked, the registry validates it, see
+// registry.FeaturesFlag
 func (f *AdvancedFeatures) Set(value string) error {
-	for _, advancedFeature := range AllowedAdvancedFeatures {
-		if advancedFeature == value {
-			*f = append(*f, advancedFeature)
-			return nil
-		}
-	}
-
-	return fmt.Errorf("advanced Feature to use. Allowed values: %s", strings.Join(AllowedAdvancedFeatures, ", "))
+	*f = append(*f, value)
+	return nil
 }
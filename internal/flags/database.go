@@ -1,10 +1,5 @@
 package flags
 
-import (
-	"fmt"
-	"strings"
-)
-
 type Database string
 
 const (
@@ -16,8 +11,6 @@ const (
 	None     Database = "none"
 )
 
-var AllowedDBDrivers = []string{string(MySql), string(Postgres), string(Sqlite), string(Mongo), string(Redis), string(None)}
-
 func (f Database) String() string {
 	return string(f)
 }
@@ -26,12 +19,9 @@ func (f *Database) Type() string {
 	return "Database"
 }
 
+// Set stores value unchecked, the registry validates it, see
+// registry.DriverFlag
 func (f *Database) Set(value string) error {
-	for _, database := range AllowedDBDrivers {
-		if database == value {
-			*f = Database(value)
-			return nil
-		}
-	}
-	return fmt.Errorf("Database to use. Allowed values: %s", strings.Join(AllowedDBDrivers, ", "))
+	*f = Database(value)
+	return nil
 }
@@ -1,15 +1,10 @@
 package flags
 
-import (
-	"fmt"
-	"strings"
-)
-
 type Framework string
 
-// These are all the current frameworks supported. If you want to add one, you
-// can simply copy and paste a line here. Do not forget to also add it into the
-// AllowedProjectTypes slice too!
+// These are the frameworks supported. To add one, register it in
+// registry.Frameworks, a constant here is only a convenience for code that
+// refers to it.
 const (
 	Chi             Framework = "chi"
 	Gin             Framework = "gin"
@@ -18,10 +13,10 @@ const (
 	HttpRouter      Framework = "httprouter"
 	StandardLibrary Framework = "standard-library"
 	Echo            Framework = "echo"
+	Grpc            Framework = "grpc"
+	Cli             Framework = "cli"
 )
 
-var AllowedProjectTypes = []string{string(Chi), string(Gin), string(Fiber), string(GorillaMux), string(HttpRouter), string(StandardLibrary), string(Echo)}
-
 func (f Framework) String() string {
 	return string(f)
 }
@@ -30,13 +25,9 @@ func (f *Framework) Type() string {
 	return "Framework"
 }
 
+// Set stores value unchecked, the registry validates it, see
+// registry.FrameworkFlag
 func (f *Framework) Set(value string) error {
-	for _, project := range AllowedProjectTypes {
-		if project == value {
-			*f = Framework(value)
-			return nil
-		}
-	}
-
-	return fmt.Errorf("Framework to use. Allowed values: %s", strings.Join(AllowedProjectTypes, ", "))
+	*f = Framework(value)
+	return nil
 }
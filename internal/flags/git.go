@@ -1,10 +1,5 @@
 package flags
 
-import (
-	"fmt"
-	"strings"
-)
-
 type Git string
 
 const (
@@ -13,8 +8,6 @@ const (
 	Skip   = "skip"
 )
 
-var AllowedGitsOptions = []string{string(Commit), string(Stage), string(Skip)}
-
 func (f Git) String() string {
 	return string(f)
 }
@@ -23,13 +16,9 @@ func (f *Git) Type() string {
 	return "Git"
 }
 
+// Set stores value unchecked, the registry validates it, see
+// registry.GitFlag
 func (f *Git) Set(value string) error {
-	for _, gitOption := range AllowedGitsOptions {
-		if gitOption == value {
-			*f = Git(value)
-			return nil
-		}
-	}
-
-	return fmt.Errorf("Git to use. Allowed values: %s", strings.Join(AllowedGitsOptions, ", "))
+	*f = Git(value)
+	return nil
 }
@@ -0,0 +1,37 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+type PackageManager string
+
+// These are the package managers the frontend can be created and run with
+const (
+	Npm  PackageManager = "npm"
+	Pnpm PackageManager = "pnpm"
+	Yarn PackageManager = "yarn"
+	Bun  PackageManager = "bun"
+)
+
+var AllowedPackageManagers = []string{string(Npm), string(Pnpm), string(Yarn), string(Bun)}
+
+func (f PackageManager) String() string {
+	return string(f)
+}
+
+func (f *PackageManager) Type() string {
+	return "PackageManager"
+}
+
+func (f *PackageManager) Set(value string) error {
+	for _, packageManager := range AllowedPackageManagers {
+		if packageManager == value {
+			*f = PackageManager(value)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Package manager to use. Allowed values: %s", strings.Join(AllowedPackageManagers, ", "))
+}
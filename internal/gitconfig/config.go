@@ -1,13 +1,18 @@
 package gitconfig
 
 import (
+	"context"
+	"errors"
 	"os/exec"
+
+	"github.com/mahibulhaque/gofast/internal/executor"
 )
 
-func CheckConfig(key string) (bool, error) {
-	cmd := exec.Command("git", "config", "--get", key)
-	if err := cmd.Run(); err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
+func CheckConfig(ctx context.Context, runner executor.CommandRunner, key string) (bool, error) {
+	err := runner.Run(ctx, "git", []string{"config", "--get", key}, "")
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
 			// The command failed to run.
 			if exitError.ExitCode() == 1 {
 				// The 'git config --get' command returns 1 if the key was not found.
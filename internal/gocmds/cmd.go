@@ -1,11 +1,15 @@
 package gocmds
 
-import "github.com/mahibulhaque/gofast/internal/executor"
+import (
+	"context"
+
+	"github.com/mahibulhaque/gofast/internal/executor"
+)
 
 // InitGoMod initializes go.mod with the given project name
 // in the selected directory
-func InitGoMod(projectName string, appDir string) error {
-	if err := executor.ExecuteCmd("go",
+func InitGoMod(ctx context.Context, runner executor.CommandRunner, projectName string, appDir string) error {
+	if err := runner.Run(ctx, "go",
 		[]string{"mod", "init", projectName},
 		appDir); err != nil {
 		return err
@@ -16,9 +20,9 @@ func InitGoMod(projectName string, appDir string) error {
 
 // GoGetPackage runs "go get" for a given package in the
 // selected directory
-func GoGetPackage(appDir string, packages []string) error {
+func GoGetPackage(ctx context.Context, runner executor.CommandRunner, appDir string, packages []string) error {
 	for _, packageName := range packages {
-		if err := executor.ExecuteCmd("go",
+		if err := runner.Run(ctx, "go",
 			[]string{"get", "-u", packageName},
 			appDir); err != nil {
 			return err
@@ -30,8 +34,8 @@ func GoGetPackage(appDir string, packages []string) error {
 
 // GoFmt runs "gofmt" in a selected directory using the
 // simplify and overwrite flags
-func GoFmt(appDir string) error {
-	if err := executor.ExecuteCmd("gofmt",
+func GoFmt(ctx context.Context, runner executor.CommandRunner, appDir string) error {
+	if err := runner.Run(ctx, "gofmt",
 		[]string{"-s", "-w", "."},
 		appDir); err != nil {
 		return err
@@ -42,8 +46,8 @@ func GoFmt(appDir string) error {
 
 // GoModReplace runs "go mod edit -replace" in the selected
 // replace_payload e.g: github.com/gocql/gocql=github.com/scylladb/gocql@v1.14.4
-func GoModReplace(appDir string, replace string) error {
-	if err := executor.ExecuteCmd("go",
+func GoModReplace(ctx context.Context, runner executor.CommandRunner, appDir string, replace string) error {
+	if err := runner.Run(ctx, "go",
 		[]string{"mod", "edit", "-replace", replace},
 		appDir,
 	); err != nil {
@@ -53,8 +57,8 @@ func GoModReplace(appDir string, replace string) error {
 	return nil
 }
 
-func GoTidy(appDir string) error {
-	err := executor.ExecuteCmd("go", []string{"mod", "tidy"}, appDir)
+func GoTidy(ctx context.Context, runner executor.CommandRunner, appDir string) error {
+	err := runner.Run(ctx, "go", []string{"mod", "tidy"}, appDir)
 	if err != nil {
 		return err
 	}
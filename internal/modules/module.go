@@ -1,10 +1,20 @@
 package modules
 
 import (
+	"errors"
+	"fmt"
+	"go/token"
 	"regexp"
 	"strings"
 )
 
+var (
+	invalidModuleChars  = regexp.MustCompile(`[^a-zA-Z0-9_\-/.]+`)
+	repeatedSeparators  = regexp.MustCompile(`[/.]*/[/.]*`)
+	repeatedDots        = regexp.MustCompile(`\.{2,}`)
+	reservedFirstTokens = map[string]bool{"std": true, "cmd": true}
+)
+
 // ValidateModuleName returns true if it's a valid module name.
 // It allows any number of / and . characters in between.
 func ValidateModuleName(moduleName string) bool {
@@ -12,6 +22,49 @@ func ValidateModuleName(moduleName string) bool {
 	return matched
 }
 
+// CheckModuleName explains why moduleName cannot be used as the module path
+// of a new project, or returns nil
+func CheckModuleName(moduleName string) error {
+	if moduleName == "" {
+		return errors.New("the name must not be empty")
+	}
+	if !ValidateModuleName(moduleName) {
+		return fmt.Errorf("'%s' is not a valid module path, use letters, digits, '_' and '-' separated by '/' or '.'", moduleName)
+	}
+	if first := strings.Split(moduleName, "/")[0]; reservedFirstTokens[first] {
+		return fmt.Errorf("module paths starting with '%s' are reserved by the go command", first)
+	}
+	if root := GetRootDir(moduleName); token.IsKeyword(root) {
+		return fmt.Errorf("'%s' is a Go keyword and cannot name the project directory and package", root)
+	}
+	return nil
+}
+
+// SuggestModuleName returns a module path close to moduleName that passes
+// CheckModuleName, e.g. "my-app" for "my app". It returns "" when no
+// suggestion can be made
+func SuggestModuleName(moduleName string) string {
+	suggestion := strings.TrimSpace(moduleName)
+	suggestion = invalidModuleChars.ReplaceAllString(suggestion, "-")
+	suggestion = repeatedSeparators.ReplaceAllString(suggestion, "/")
+	suggestion = repeatedDots.ReplaceAllString(suggestion, ".")
+	suggestion = strings.Trim(suggestion, "/.")
+
+	tokens := strings.Split(suggestion, "/")
+	if reservedFirstTokens[tokens[0]] {
+		tokens[0] = "my" + tokens[0]
+	}
+	if last := len(tokens) - 1; token.IsKeyword(tokens[last]) {
+		tokens[last] += "app"
+	}
+	suggestion = strings.Join(tokens, "/")
+
+	if CheckModuleName(suggestion) != nil {
+		return ""
+	}
+	return suggestion
+}
+
 // GetRootDir returns the project directory name from the module path.
 // Returns the last token by splitting the moduleName with /
 func GetRootDir(moduleName string) string {
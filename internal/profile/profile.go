@@ -0,0 +1,238 @@
+// Package profile holds named presets of the create options. Besides the
+// built-in profiles, every "<name>.toml" file in the profiles directory of
+// the gofast config directory defines one, in the syntax of config.toml,
+// e.g.
+//
+//	# ~/.config/gofast/profiles/team-api.toml
+//	description = "Our usual REST API"
+//	framework = "chi"
+//	driver = "postgres"
+//	features = ["docker", "githubaction"]
+//	git = "commit"
+//
+//	[vars]
+//	port = "3000"
+//
+// A user profile with the name of a built-in profile replaces it.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/config"
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/registry"
+	"github.com/mahibulhaque/gofast/internal/toml"
+	"github.com/mahibulhaque/gofast/internal/vars"
+)
+
+// Extension is the file extension of user profiles
+const Extension = ".toml"
+
+// Profile preselects create options. Empty options are left to flags and
+// prompts
+type Profile struct {
+	Name        string
+	Description string
+	Framework   flags.Framework
+	Driver      flags.Database
+	Features    []string
+	Git         flags.Git
+	// Vars holds key=value template variable assignments
+	Vars []string
+	// Path is the file the profile was read from, empty for built-in ones
+	Path string
+	// Err is the reason the profile file could not be read. Such a profile
+	// is listed, but only fails the run when it is chosen
+	Err error
+}
+
+// Builtin are the profiles that are always available
+var Builtin = []Profile{
+	{
+		Name:        "api-minimal",
+		Description: "Standard library HTTP API without a database",
+		Framework:   flags.StandardLibrary,
+		Driver:      flags.None,
+		Git:         flags.Commit,
+	},
+	{
+		Name:        "fullstack",
+		Description: "Chi API with Postgres, a React frontend and Docker",
+		Framework:   flags.Chi,
+		Driver:      flags.Postgres,
+		Features:    []string{flags.React, flags.Docker},
+		Git:         flags.Commit,
+	},
+	{
+		Name:        "microservice",
+		Description: "Chi service with Postgres, Docker and a CI/CD workflow",
+		Framework:   flags.Chi,
+		Driver:      flags.Postgres,
+		Features:    []string{flags.Docker, flags.GoProjectWorkflow},
+		Git:         flags.Commit,
+	},
+	{
+		Name:        "worker",
+		Description: "Background worker with a Redis job queue, shipped with Docker",
+		Framework:   flags.StandardLibrary,
+		Driver:      flags.Redis,
+		Features:    []string{flags.Worker, flags.Docker},
+		Git:         flags.Commit,
+	},
+}
+
+// Dir returns the directory user profiles are read from
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profiles"), nil
+}
+
+// All returns the built-in profiles and the user profiles of Dir
+func All() ([]Profile, error) {
+	dir, err := Dir()
+	if err != nil {
+		// Without a config directory there are only the built-in profiles
+		return Load("")
+	}
+	return Load(dir)
+}
+
+// Load returns the built-in profiles and the user profiles of dir, sorted
+// by name. A missing dir only yields the built-in profiles, an invalid
+// profile file yields a profile holding its Err
+func Load(dir string) ([]Profile, error) {
+	byName := make(map[string]Profile)
+	for _, p := range Builtin {
+		byName[p.Name] = p
+	}
+
+	var entries []fs.DirEntry
+	var err error
+	if dir != "" {
+		entries, err = os.ReadDir(dir)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("could not read profiles: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != Extension {
+			continue
+		}
+
+		p := readFile(filepath.Join(dir, entry.Name()))
+		byName[p.Name] = p
+	}
+
+	profiles := make([]Profile, 0, len(byName))
+	for _, p := range byName {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// Names returns the names of profiles
+func Names(profiles []Profile) []string {
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Lookup returns the profile called name
+func Lookup(profiles []Profile, name string) (Profile, error) {
+	for _, p := range profiles {
+		if p.Name == name {
+			return p, p.Err
+		}
+	}
+	return Profile{}, fmt.Errorf("unknown profile '%s'. Available profiles: %s", name, strings.Join(Names(profiles), ", "))
+}
+
+// readFile returns the profile defined by the file at path, its Err is set
+// when the file is invalid
+func readFile(path string) Profile {
+	name := strings.TrimSuffix(filepath.Base(path), Extension)
+	file, err := os.Open(path)
+	if err != nil {
+		return Profile{Name: name, Path: path, Err: fmt.Errorf("could not read profile: %w", err)}
+	}
+	defer file.Close()
+
+	p, err := Parse(name, file)
+	p.Path = path
+	if err != nil {
+		p.Err = fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+	return p
+}
+
+// Parse reads a profile written in the TOML syntax of config.toml
+func Parse(name string, r io.Reader) (Profile, error) {
+	p := Profile{Name: name}
+	err := toml.Decode(r, func(key string, value toml.Value) error {
+		return p.set(key, value)
+	})
+	return p, err
+}
+
+func (p *Profile) set(key string, value toml.Value) error {
+	if varKey, ok := strings.CutPrefix(key, "vars."); ok {
+		if value.IsArray {
+			return fmt.Errorf("%s: expected a string, not an array", key)
+		}
+		check := vars.Defaults()
+		if err := check.Set(varKey, value.String); err != nil {
+			return err
+		}
+		p.Vars = append(p.Vars, varKey+"="+value.String)
+		return nil
+	}
+
+	if key == "features" {
+		if !value.IsArray {
+			return fmt.Errorf("features: expected an array, e.g. [\"docker\"]")
+		}
+		for _, value := range value.Array {
+			feature, err := registry.ParseFeature(value)
+			if err != nil {
+				return fmt.Errorf("features: %w", err)
+			}
+			p.Features = append(p.Features, feature)
+		}
+		return nil
+	}
+
+	if value.IsArray {
+		return fmt.Errorf("%s: expected a string, not an array", key)
+	}
+	var err error
+	switch key {
+	case "description":
+		p.Description = value.String
+	case "framework":
+		p.Framework, err = registry.ParseFramework(value.String)
+	case "driver":
+		p.Driver, err = registry.ParseDriver(value.String)
+	case "git":
+		p.Git, err = registry.ParseGit(value.String)
+	default:
+		return fmt.Errorf("unknown key '%s'. Allowed keys: description, framework, driver, features, git and the keys of the [vars] table", key)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
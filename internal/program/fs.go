@@ -0,0 +1,217 @@
+package program
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS is the filesystem a project is written to.
+type FS interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	ReadFile(name string) ([]byte, error)
+	Remove(name string) error
+}
+
+// OSFS writes to the local disk.
+type OSFS struct{}
+
+func (OSFS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (OSFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (OSFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// MemFile is a file or directory held by a MemFS.
+type MemFile struct {
+	Data    []byte
+	Mode    fs.FileMode
+	ModTime time.Time
+}
+
+// MemFS keeps a generated project in memory. Paths are stored cleaned and
+// slash separated. It implements fs.FS, so a project rendered into it can
+// be read back with the io/fs helpers, e.g. to archive it.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string]MemFile
+}
+
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(map[string]MemFile)}
+}
+
+func (m *MemFS) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for dir := memPath(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if f, ok := m.files[dir]; ok {
+			if !f.Mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+			}
+			continue
+		}
+		m.files[dir] = MemFile{Mode: fs.ModeDir | perm.Perm(), ModTime: time.Now()}
+	}
+	return nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = memPath(name)
+	if f, ok := m.files[path.Dir(name)]; path.Dir(name) != "." && (!ok || !f.Mode.IsDir()) {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if f, ok := m.files[name]; ok {
+		if f.Mode.IsDir() {
+			return &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+		}
+		// Like os.WriteFile, an existing file keeps its permissions
+		perm = f.Mode
+	}
+	m.files[name] = MemFile{Data: append([]byte(nil), data...), Mode: perm.Perm(), ModTime: time.Now()}
+	return nil
+}
+
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, ok := m.files[memPath(name)]
+	if !ok || f.Mode.IsDir() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), f.Data...), nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = memPath(name)
+	if _, ok := m.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	for p := range m.files {
+		if strings.HasPrefix(p, name+"/") {
+			return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+		}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+// Open implements fs.FS.
+func (m *MemFS) Open(name string) (fs.File, error) {
+	info, err := m.stat(name)
+	if err != nil {
+		err.Op = "open"
+		return nil, err
+	}
+	return &memOpenFile{info: info, reader: bytes.NewReader(info.file.Data)}, nil
+}
+
+// Stat implements fs.StatFS.
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	info, err := m.stat(name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	dir, err := m.stat(name)
+	if err != nil {
+		err.Op = "readdir"
+		return nil, err
+	}
+	if !dir.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var entries []fs.DirEntry
+	for p, f := range m.files {
+		if p != dir.path && path.Dir(p) == dir.path {
+			entries = append(entries, fs.FileInfoToDirEntry(&memFileInfo{path: p, file: f}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (m *MemFS) stat(name string) (*memFileInfo, *fs.PathError) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if name == "." {
+		// The root always exists, even before anything was written
+		return &memFileInfo{path: ".", file: MemFile{Mode: fs.ModeDir | 0o755}}, nil
+	}
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFileInfo{path: name, file: f}, nil
+}
+
+// memFileInfo describes a MemFile, it implements fs.FileInfo
+type memFileInfo struct {
+	path string
+	file MemFile
+}
+
+func (i *memFileInfo) Name() string       { return path.Base(i.path) }
+func (i *memFileInfo) Size() int64        { return int64(len(i.file.Data)) }
+func (i *memFileInfo) Mode() fs.FileMode  { return i.file.Mode }
+func (i *memFileInfo) ModTime() time.Time { return i.file.ModTime }
+func (i *memFileInfo) IsDir() bool        { return i.file.Mode.IsDir() }
+func (i *memFileInfo) Sys() any           { return nil }
+
+// memOpenFile is a MemFile opened for reading, it implements fs.File
+type memOpenFile struct {
+	info   *memFileInfo
+	reader *bytes.Reader
+}
+
+func (f *memOpenFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memOpenFile) Read(b []byte) (int, error) {
+	if f.info.IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.info.path, Err: errors.New("is a directory")}
+	}
+	return f.reader.Read(b)
+}
+func (f *memOpenFile) Close() error { return nil }
+
+func memPath(name string) string {
+	return path.Clean(filepath.ToSlash(name))
+}
@@ -2,26 +2,32 @@ package program
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"text/template" // Changed from "html/template" to "text/template"
+	"go/format"
+	"io/fs"
 	"log"
+	"maps"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"text/template" // Changed from "html/template" to "text/template"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/mahibulhaque/gofast/internal/executor"
 	"github.com/mahibulhaque/gofast/internal/flags"
-	gitconfig "github.com/mahibulhaque/gofast/internal/gitconfig"
+	"github.com/mahibulhaque/gofast/internal/gitconfig"
 	"github.com/mahibulhaque/gofast/internal/gocmds"
 	"github.com/mahibulhaque/gofast/internal/modules"
+	"github.com/mahibulhaque/gofast/internal/registry"
 	tpl "github.com/mahibulhaque/gofast/internal/template"
 	"github.com/mahibulhaque/gofast/internal/template/advanced"
-	"github.com/mahibulhaque/gofast/internal/template/dbdriver"
-	"github.com/mahibulhaque/gofast/internal/template/docker"
 	"github.com/mahibulhaque/gofast/internal/template/framework"
+	"github.com/mahibulhaque/gofast/internal/template/protobuf"
+	"github.com/mahibulhaque/gofast/internal/vars"
 )
 
 type Project struct {
@@ -38,6 +44,24 @@ type Project struct {
 	AdvancedTemplates AdvancedTemplates
 	GitOptions        flags.Git
 	OSCheck           map[string]bool
+	// Vars holds the values of the template variables, the documented
+	// defaults when left zero
+	Vars vars.Vars
+	// Author is named in the generated README when set
+	Author string
+	// PackageManager installs and runs the frontend, npm when empty
+	PackageManager flags.PackageManager
+	// InstallFrontend installs the dependencies of the frontend once it is
+	// written, otherwise make run does
+	InstallFrontend bool
+
+	// FS is the filesystem the project is written to, the local disk when nil
+	FS FS
+	// Runner runs every external command, executor.Default when nil
+	Runner executor.CommandRunner
+	// SkipCommands only renders the files without running go, gofmt, git
+	// or npm. The generated go.mod then has no requirements yet
+	SkipCommands bool
 }
 
 type AdvancedTemplates struct {
@@ -60,24 +84,11 @@ type Docker struct {
 	templater   DockerTemplater
 }
 
-type Templater interface {
-	Main() []byte
-	Server() []byte
-	Routes() []byte
-	WebsocketImports() []byte
-	RequestPackage() []byte
-	ResponsePackage() []byte
-}
-
-type DBDriverTemplater interface {
-	Service() []byte
-	Env() []byte
-	Tests() []byte
-}
-
-type DockerTemplater interface {
-	Docker() []byte
-}
+type (
+	Templater         = registry.Templater
+	DBDriverTemplater = registry.DBDriverTemplater
+	DockerTemplater   = registry.DockerTemplater
+)
 
 type WorkflowTemplater interface {
 	Releaser() []byte
@@ -86,19 +97,6 @@ type WorkflowTemplater interface {
 }
 
 var (
-	chiPackage     = []string{"github.com/go-chi/chi/v5"}
-	gorillaPackage = []string{"github.com/gorilla/mux"}
-	routerPackage  = []string{"github.com/julienschmidt/httprouter"}
-	ginPackage     = []string{"github.com/gin-gonic/gin"}
-	fiberPackage   = []string{"github.com/gofiber/fiber/v2"}
-	echoPackage    = []string{"github.com/labstack/echo/v4", "github.com/labstack/echo/v4/middleware"}
-
-	mysqlDriver    = []string{"github.com/go-sql-driver/mysql"}
-	postgresDriver = []string{"github.com/jackc/pgx/v5/stdlib"}
-	sqliteDriver   = []string{"github.com/mattn/go-sqlite3"}
-	redisDriver    = []string{"github.com/redis/go-redis/v9"}
-	mongoDriver    = []string{"go.mongodb.org/mongo-driver"}
-
 	godotenvPackage = []string{"github.com/joho/godotenv"}
 )
 
@@ -111,6 +109,10 @@ const (
 	internalRequestPackagePath  = "internal/request"
 	internalResponsePackagePath = "internal/response"
 	githubActionPath            = ".github/workflows"
+
+	// defaultGoVersion is written to go.mod when the version of the running
+	// toolchain cannot be determined
+	defaultGoVersion = "1.23"
 )
 
 func (p *Project) CheckOS() {
@@ -137,105 +139,91 @@ func (p *Project) ExitCLI(tprogram *tea.Program) {
 	}
 }
 
+// createFrameworkMap registers every framework of the registry
 func (p *Project) createFrameworkMap() {
-	p.FrameworkMap[flags.Chi] = Framework{
-		packageName: chiPackage,
-		templater:   framework.ChiTemplates{},
-	}
-	p.FrameworkMap[flags.StandardLibrary] = Framework{
-		packageName: []string{},
-		templater:   framework.StandardLibTemplate{},
-	}
-
-	p.FrameworkMap[flags.Gin] = Framework{
-		packageName: ginPackage,
-		templater:   framework.GinTemplates{},
+	for _, f := range registry.Frameworks {
+		p.FrameworkMap[f.Value] = Framework{
+			packageName: f.Packages,
+			templater:   f.Templater,
+		}
 	}
+}
 
-	p.FrameworkMap[flags.Fiber] = Framework{
-		packageName: fiberPackage,
-		templater:   framework.FiberTemplates{},
+// createDBDriverMap registers every driver of the registry that adds code
+func (p *Project) createDBDriverMap() {
+	for _, d := range registry.Drivers {
+		if d.Templater == nil {
+			continue
+		}
+		p.DBDriverMap[d.Value] = DBDriver{
+			packageName: d.Packages,
+			templater:   d.Templater,
+		}
 	}
+}
 
-	p.FrameworkMap[flags.GorillaMux] = Framework{
-		packageName: gorillaPackage,
-		templater:   framework.GorillaTemplates{},
-	}
+// createDockerMap registers every driver of the registry that runs in a
+// container
+func (p *Project) createDockerMap() {
+	p.DockerMap = make(map[flags.Database]Docker)
 
-	p.FrameworkMap[flags.HttpRouter] = Framework{
-		packageName: routerPackage,
-		templater:   framework.RouterTemplates{},
+	for _, d := range registry.Drivers {
+		if d.Docker == nil {
+			continue
+		}
+		p.DockerMap[d.Value] = Docker{
+			packageName: []string{},
+			templater:   d.Docker,
+		}
 	}
+}
 
-	p.FrameworkMap[flags.Echo] = Framework{
-		packageName: echoPackage,
-		templater:   framework.EchoTemplates{},
+// fs returns the filesystem the project is written to
+func (p *Project) fs() FS {
+	if p.FS == nil {
+		return OSFS{}
 	}
+	return p.FS
 }
 
-func (p *Project) createDBDriverMap() {
-
-	p.DBDriverMap[flags.MySql] = DBDriver{
-		packageName: mysqlDriver,
-		templater:   dbdriver.MysqlTemplate{},
-	}
-	p.DBDriverMap[flags.Postgres] = DBDriver{
-		packageName: postgresDriver,
-		templater:   dbdriver.PostgresTemplate{},
-	}
-	p.DBDriverMap[flags.Sqlite] = DBDriver{
-		packageName: sqliteDriver,
-		templater:   dbdriver.SqliteTemplate{},
-	}
-	p.DBDriverMap[flags.Mongo] = DBDriver{
-		packageName: mongoDriver,
-		templater:   dbdriver.MongoTemplate{},
-	}
-	p.DBDriverMap[flags.Redis] = DBDriver{
-		packageName: redisDriver,
-		templater:   dbdriver.RedisTemplate{},
+// runner returns the runner used for every external command
+func (p *Project) runner() executor.CommandRunner {
+	if p.Runner == nil {
+		return executor.Default
 	}
+	return p.Runner
 }
 
-func (p *Project) createDockerMap() {
-	p.DockerMap = make(map[flags.Database]Docker)
-
-	p.DockerMap[flags.MySql] = Docker{
-		packageName: []string{},
-		templater:   docker.MysqlDockerTemplate{},
+func (p *Project) CreateMainFile(ctx context.Context) error {
+	if p.FrameworkMap == nil {
+		p.FrameworkMap = make(map[flags.Framework]Framework)
 	}
-	p.DockerMap[flags.Postgres] = Docker{
-		packageName: []string{},
-		templater:   docker.PostgresDockerTemplate{},
+	if p.DBDriverMap == nil {
+		p.DBDriverMap = make(map[flags.Database]DBDriver)
 	}
-	p.DockerMap[flags.Mongo] = Docker{
-		packageName: []string{},
-		templater:   docker.MongoDockerTemplate{},
+	if p.AdvancedOptions == nil {
+		p.AdvancedOptions = make(map[string]bool)
 	}
-	p.DockerMap[flags.Redis] = Docker{
-		packageName: []string{},
-		templater:   docker.RedisDockerTemplate{},
+	if p.Vars == (vars.Vars{}) {
+		p.Vars = vars.Defaults()
+	}
+	if p.PackageManager == "" {
+		p.PackageManager = flags.Npm
 	}
-}
 
-func (p *Project) CreateMainFile() error {
-	if _, err := os.Stat(p.AbsolutePath); os.IsNotExist(err) {
-		if err := os.Mkdir(p.AbsolutePath, 0o754); err != nil {
-			log.Printf("Could not create directory: %v", err)
-			return err
+	if p.AbsolutePath != "" {
+		if err := p.fs().MkdirAll(p.AbsolutePath, 0o754); err != nil {
+			return fmt.Errorf("could not create directory: %w", err)
 		}
 	}
 
-	if p.GitOptions.String() != flags.Skip {
-
-		emailSet, err := gitconfig.CheckConfig("user.email")
+	if p.GitOptions.String() != flags.Skip && !p.SkipCommands {
+		emailSet, err := gitconfig.CheckConfig(ctx, p.runner(), "user.email")
 		if err != nil {
 			return err
 		}
 		if !emailSet {
-			fmt.Println("user.email is not set in git config.")
-			fmt.Println("Please set up git config before trying again.")
-			panic("\nGIT CONFIG ISSUE: user.email is not set in git config.\n")
+			return errors.New("user.email is not set in git config, please set up git config before trying again")
 		}
 	}
 
@@ -243,12 +231,8 @@ func (p *Project) CreateMainFile() error {
 
 	projectPath := filepath.Join(p.AbsolutePath, modules.GetRootDir(p.ProjectName))
 
-	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-		err := os.MkdirAll(projectPath, 0o751)
-		if err != nil {
-			log.Printf("Error creating root project directory %v\n", err)
-			return err
-		}
+	if err := p.fs().MkdirAll(projectPath, 0o751); err != nil {
+		return fmt.Errorf("error creating root project directory: %w", err)
 	}
 
 	// Define Operating system
@@ -257,132 +241,88 @@ func (p *Project) CreateMainFile() error {
 	// Create the map for our program
 	p.createFrameworkMap()
 
-	err := gocmds.InitGoMod(p.ProjectName, projectPath)
+	err := p.initGoMod(ctx, projectPath)
 	if err != nil {
-		log.Printf("Could not initialize go.mod in new project %v\n", err)
-		return err
+		return fmt.Errorf("could not initialize go.mod in new project: %w", err)
 	}
 
 	// Install the correct package for the selected framework
 	if p.ProjectType != flags.StandardLibrary {
-		err = gocmds.GoGetPackage(projectPath, p.FrameworkMap[p.ProjectType].packageName)
+		err = p.goGetPackage(ctx, projectPath, p.FrameworkMap[p.ProjectType].packageName)
 		if err != nil {
-			log.Println("Could not install go dependency for the chosen framework")
-			return err
+			return fmt.Errorf("could not install go dependency for the chosen framework: %w", err)
 		}
 	}
 
 	if p.DBDriver != "none" {
 		p.createDBDriverMap()
 
-		err = gocmds.GoGetPackage(projectPath, p.DBDriverMap[p.DBDriver].packageName)
-
+		err = p.goGetPackage(ctx, projectPath, p.DBDriverMap[p.DBDriver].packageName)
 		if err != nil {
-			log.Println("Could not install go dependency for chosen driver")
-
-			return err
+			return fmt.Errorf("could not install go dependency for chosen driver: %w", err)
 		}
 
 		err = p.CreatePath(internalDatabasePath, projectPath)
-
 		if err != nil {
-			log.Printf("Error creating path: %s", internalDatabasePath)
-
 			return err
 		}
 
 		err = p.CreateFileWithInjection(internalDatabasePath, projectPath, "database.go", "database")
 		if err != nil {
-			log.Printf("Error injecting db.go file: %v", err)
-			return err
+			return fmt.Errorf("error injecting database.go file: %w", err)
 		}
 
 		if p.DBDriver != "sqlite" {
 			err = p.CreateFileWithInjection(internalDatabasePath, projectPath, "database_test.go", "integration-tests")
 			if err != nil {
-				log.Printf("Error injecting database_test.go file: %v", err)
-				return err
+				return fmt.Errorf("error injecting database_test.go file: %w", err)
 			}
 		}
 	}
 
-	if p.DBDriver != "none" && p.DBDriver != "sqlite" {
-		p.createDockerMap()
+	p.createDockerMap()
+	if _, ok := p.DockerMap[p.DBDriver]; ok {
 		p.Docker = p.DBDriver
+	}
 
-		err = p.CreateFileWithInjection(root, projectPath, "docker-compose.yml", "db-docker")
+	// A database container or the docker feature each need a compose file,
+	// the one of the database also runs the app when docker is selected
+	if p.Docker != "" || p.AdvancedOptions[flags.Docker] {
+		err = p.CreateFileWithInjection(root, projectPath, "docker-compose.yml", "docker-compose")
 		if err != nil {
-			log.Printf("Error injecting docker-compose.yml file: %v", err)
-			return err
+			return fmt.Errorf("error injecting docker-compose.yml file: %w", err)
 		}
 	}
 
-	err = gocmds.GoGetPackage(projectPath, godotenvPackage)
-
-	if err != nil {
-		log.Println("Could not install go dependency")
-
-		return err
-	}
-
-	err = p.CreatePath(cmdApiPath, projectPath)
+	err = p.goGetPackage(ctx, projectPath, godotenvPackage)
 	if err != nil {
-		log.Printf("Error creating path: %s", projectPath)
-		return err
+		return fmt.Errorf("could not install go dependency: %w", err)
 	}
 
-	err = p.CreateFileWithInjection(cmdApiPath, projectPath, "main.go", "main")
+	err = p.CreatePath(p.MainPath(), projectPath)
 	if err != nil {
 		return err
 	}
 
-	makeFile, err := os.Create(filepath.Join(projectPath, "Makefile"))
-	if err != nil {
-		return err
-	}
-
-	defer makeFile.Close()
-
-	makeFileTemplate := template.Must(template.New("makefile").Parse(string(framework.MakeTemplate())))
-	err = makeFileTemplate.Execute(makeFile, p)
+	err = p.CreateFileWithInjection(p.MainPath(), projectPath, "main.go", "main")
 	if err != nil {
-		return err
+		return fmt.Errorf("error injecting main.go file: %w", err)
 	}
 
-	readmeFile, err := os.Create(filepath.Join(projectPath, "README.md"))
+	err = p.writeTemplate(filepath.Join(projectPath, "Makefile"), framework.MakeTemplate())
 	if err != nil {
 		return err
 	}
-	defer readmeFile.Close()
 
 	// inject readme template
-	readmeFileTemplate := template.Must(template.New("readme").Parse(string(framework.ReadmeTemplate())))
-	err = readmeFileTemplate.Execute(readmeFile, p)
-	if err != nil {
-		return err
-	}
-
-	err = p.CreatePath(internalServerPath, projectPath)
-	if err != nil {
-		log.Printf("Error creating path: %s", internalServerPath)
-		return err
-	}
-
-	err = p.CreatePath(internalRequestPackagePath, projectPath)
+	err = p.writeTemplate(filepath.Join(projectPath, "README.md"), framework.ReadmeTemplate())
 	if err != nil {
-		log.Printf("Error creating path: %s", internalRequestPackagePath)
 		return err
 	}
 
-	err = p.CreatePath(internalResponsePackagePath, projectPath)
-	if err != nil {
-		log.Printf("Error creating path: %s", internalResponsePackagePath)
-		return err
-	}
-
-	if p.AdvancedOptions[string(flags.React)] {
-		if err := p.CreateViteReactProject(projectPath); err != nil {
-			return fmt.Errorf("failed to set up React project: %w", err)
+	if frontend := p.Frontend(); frontend != "" {
+		if err := p.CreateFrontend(ctx, projectPath); err != nil {
+			return fmt.Errorf("failed to set up %s project: %w", frontend, err)
 		}
 	}
 
@@ -390,181 +330,346 @@ func (p *Project) CreateMainFile() error {
 	if p.AdvancedOptions[string(flags.GoProjectWorkflow)] {
 		err = p.CreatePath(githubActionPath, projectPath)
 		if err != nil {
-			log.Printf("Error creating path: %s", githubActionPath)
 			return err
 		}
 
 		err = p.CreateFileWithInjection(githubActionPath, projectPath, "release.yml", "releaser")
 		if err != nil {
-			log.Printf("Error injecting release.yml file: %v", err)
-			return err
+			return fmt.Errorf("error injecting release.yml file: %w", err)
 		}
 
 		err = p.CreateFileWithInjection(githubActionPath, projectPath, "go-test.yml", "go-test")
 		if err != nil {
-			log.Printf("Error injecting go-test.yml file: %v", err)
-			return err
+			return fmt.Errorf("error injecting go-test.yml file: %w", err)
 		}
 
 		err = p.CreateFileWithInjection(root, projectPath, ".goreleaser.yml", "releaser-config")
 		if err != nil {
-			log.Printf("Error injecting .goreleaser.yml file: %v", err)
-			return err
+			return fmt.Errorf("error injecting .goreleaser.yml file: %w", err)
 		}
 	}
 
 	if p.AdvancedOptions[string(flags.Websocket)] {
-		p.CreateWebsocketImports(projectPath)
+		if err := p.CreateWebsocketImports(ctx, projectPath); err != nil {
+			return err
+		}
 	}
 
-	if p.AdvancedOptions[string(flags.Docker)] {
-		Dockerfile, err := os.Create(filepath.Join(projectPath, "Dockerfile"))
+	if p.AdvancedOptions[flags.Connect] {
+		if err := p.CreateConnectService(ctx, projectPath); err != nil {
+			return err
+		}
+	}
+
+	if p.AdvancedOptions[flags.GraphQL] {
+		graphql, _ := registry.LookupFeature(flags.GraphQL)
+		err = p.goGetPackage(ctx, projectPath, graphql.PackagesFor(p.ProjectType))
 		if err != nil {
+			return fmt.Errorf("could not install graphql dependency: %w", err)
+		}
+
+		if err := p.writeTemplates(projectPath, advanced.GraphqlFiles()); err != nil {
 			return err
 		}
-		defer Dockerfile.Close()
+	}
 
-		// inject Docker template
-		dockerfileTemplate := template.Must(template.New("Dockerfile").Parse(string(advanced.Dockerfile())))
-		err = dockerfileTemplate.Execute(Dockerfile, p)
+	if p.AdvancedOptions[flags.Htmx] {
+		htmx, _ := registry.LookupFeature(flags.Htmx)
+		err = p.goGetPackage(ctx, projectPath, htmx.PackagesFor(p.ProjectType))
 		if err != nil {
+			return fmt.Errorf("could not install templ dependency: %w", err)
+		}
+
+		if err := p.writeTemplates(projectPath, advanced.HtmxFiles()); err != nil {
 			return err
 		}
+	}
 
-		if p.DBDriver == "none" || p.DBDriver == "sqlite" {
+	// The web binary serves the frontend apart from the API of cmdApiPath
+	if p.AdvancedOptions[flags.Web] {
+		files := advanced.WebFiles()
+		files[cmdWebPath+"/main.go"] = advanced.WebMainTemplate()
+		if err := p.writeTemplates(projectPath, files); err != nil {
+			return err
+		}
+	}
 
-			Dockercompose, err := os.Create(filepath.Join(projectPath, "docker-compose.yml"))
-			if err != nil {
-				return err
-			}
-			defer Dockercompose.Close()
+	// The API server serves the frontend itself, see the frontend package
+	if p.AdvancedOptions[flags.Embed] {
+		if err := p.writeTemplates(projectPath, advanced.EmbedFiles()); err != nil {
+			return err
+		}
+	}
 
-			// inject DockerCompose template
-			dockerComposeTemplate := template.Must(template.New("docker-compose.yml").Parse(string(advanced.DockerCompose())))
-			err = dockerComposeTemplate.Execute(Dockercompose, p)
-			if err != nil {
-				return err
-			}
+	if p.AdvancedOptions[flags.Worker] {
+		if err := p.CreateWorker(projectPath); err != nil {
+			return err
 		}
 	}
 
-	err = p.CreateFileWithInjection(internalServerPath, projectPath, "routes.go", "routes")
-	if err != nil {
-		log.Printf("Error injecting routes.go file: %v", err)
-		return err
+	if p.AdvancedOptions[string(flags.Docker)] {
+		// inject Docker template
+		err = p.writeTemplate(filepath.Join(projectPath, "Dockerfile"), advanced.Dockerfile())
+		if err != nil {
+			return err
+		}
+
 	}
 
-	err = p.CreateFileWithInjection(internalServerPath, projectPath, "server.go", "server")
-	if err != nil {
-		log.Printf("Error injecting server.go file: %v", err)
-		return err
+	// Project types that serve nothing, such as a CLI, have no server
+	if p.FrameworkMap[p.ProjectType].templater.Server() != nil {
+		err = p.CreatePath(internalServerPath, projectPath)
+		if err != nil {
+			return err
+		}
+
+		err = p.CreateFileWithInjection(internalServerPath, projectPath, "routes.go", "routes")
+		if err != nil {
+			return fmt.Errorf("error injecting routes.go file: %w", err)
+		}
+
+		err = p.CreateFileWithInjection(internalServerPath, projectPath, "server.go", "server")
+		if err != nil {
+			return fmt.Errorf("error injecting server.go file: %w", err)
+		}
 	}
 
-	err = p.CreateFileWithInjection(internalRequestPackagePath, projectPath, "request.go", "request")
-	if err != nil {
-		log.Printf("Error injecting request.go file: %v", err)
-		return err
+	// Frameworks without request and response helpers, such as gRPC, leave
+	// the packages out
+	if p.FrameworkMap[p.ProjectType].templater.RequestPackage() != nil {
+		err = p.CreatePath(internalRequestPackagePath, projectPath)
+		if err != nil {
+			return err
+		}
+
+		err = p.CreateFileWithInjection(internalRequestPackagePath, projectPath, "request.go", "request")
+		if err != nil {
+			return fmt.Errorf("error injecting request.go file: %w", err)
+		}
 	}
 
-	err = p.CreateFileWithInjection(internalResponsePackagePath, projectPath, "response.go", "response")
-	if err != nil {
-		log.Printf("Error injecting response.go file: %v", err)
-		return err
+	if p.FrameworkMap[p.ProjectType].templater.ResponsePackage() != nil {
+		err = p.CreatePath(internalResponsePackagePath, projectPath)
+		if err != nil {
+			return err
+		}
+
+		err = p.CreateFileWithInjection(internalResponsePackagePath, projectPath, "response.go", "response")
+		if err != nil {
+			return fmt.Errorf("error injecting response.go file: %w", err)
+		}
 	}
 
-	err = p.CreateFileWithInjection(root, projectPath, ".env", "env")
-	if err != nil {
-		log.Printf("Error injecting .env file: %v", err)
+	if err := p.createFrameworkFiles(projectPath); err != nil {
 		return err
 	}
 
-	// Create gitignore
-	gitignoreFile, err := os.Create(filepath.Join(projectPath, ".gitignore"))
+	err = p.CreateFileWithInjection(root, projectPath, ".env", "env")
 	if err != nil {
-		return err
+		return fmt.Errorf("error injecting .env file: %w", err)
 	}
-	defer gitignoreFile.Close()
 
 	// inject gitignore template
-	gitignoreTemplate := template.Must(template.New(".gitignore").Parse(string(framework.GitIgnoreTemplate())))
-	err = gitignoreTemplate.Execute(gitignoreFile, p)
+	err = p.writeTemplate(filepath.Join(projectPath, ".gitignore"), framework.GitIgnoreTemplate())
 	if err != nil {
 		return err
 	}
 
-	// Create .air.toml file
-	airTomlFile, err := os.Create(filepath.Join(projectPath, ".air.toml"))
-	if err != nil {
-		return err
+	// Live reload restarts a server, a CLI is run by hand
+	if p.ProjectType != flags.Cli {
+		// inject air.toml template
+		err = p.writeTemplate(filepath.Join(projectPath, ".air.toml"), framework.AirTomlTemplate())
+		if err != nil {
+			return err
+		}
 	}
 
-	defer airTomlFile.Close()
-
-	// inject air.toml template
-	airTomlTemplate := template.Must(template.New("airtoml").Parse(string(framework.AirTomlTemplate())))
-	err = airTomlTemplate.Execute(airTomlFile, p)
-	if err != nil {
-		return err
+	// Without commands the go files are already formatted when written and
+	// there is no repository to initialize
+	if p.SkipCommands {
+		return nil
 	}
 
-	err = gocmds.GoTidy(projectPath)
+	err = gocmds.GoTidy(ctx, p.runner(), projectPath)
 	if err != nil {
-		log.Printf("Could not go tidy in new project %v\n", err)
-		return err
+		return fmt.Errorf("could not go tidy in new project: %w", err)
 	}
 
-	err = gocmds.GoFmt(projectPath)
+	err = gocmds.GoFmt(ctx, p.runner(), projectPath)
 	if err != nil {
-		log.Printf("Could not gofmt in new project %v\n", err)
-		return err
+		return fmt.Errorf("could not gofmt in new project: %w", err)
 	}
 
 	if p.GitOptions != flags.Skip {
-		nameSet, err := gitconfig.CheckConfig("user.name")
+		nameSet, err := gitconfig.CheckConfig(ctx, p.runner(), "user.name")
 		if err != nil {
 			return err
 		}
-
 		if !nameSet {
-			fmt.Println("user.name is not set in git config.")
-			fmt.Println("Please set up git config before trying again.")
-			panic("\nGIT CONFIG ISSUE: user.name is not set in git config.\n")
+			return errors.New("user.name is not set in git config, please set up git config before trying again")
 		}
+
 		// Initialize git repo
-		err = executor.ExecuteCmd("git", []string{"init"}, projectPath)
+		err = p.runner().Run(ctx, "git", []string{"init"}, projectPath)
 		if err != nil {
-			log.Printf("Error initializing git repo: %v", err)
-			return err
+			return fmt.Errorf("error initializing git repo: %w", err)
 		}
 
 		// Git add files
-		err = executor.ExecuteCmd("git", []string{"add", "."}, projectPath)
+		err = p.runner().Run(ctx, "git", []string{"add", "."}, projectPath)
 		if err != nil {
-			log.Printf("Error adding files to git repo: %v", err)
-			return err
+			return fmt.Errorf("error adding files to git repo: %w", err)
 		}
 
 		if p.GitOptions == flags.Commit {
 			// Git commit files
-			err = executor.ExecuteCmd("git", []string{"commit", "-m", "Initial commit"}, projectPath)
+			err = p.runner().Run(ctx, "git", []string{"commit", "-m", "Initial commit"}, projectPath)
 			if err != nil {
-				log.Printf("Error committing files to git repo: %v", err)
-				return err
+				return fmt.Errorf("error committing files to git repo: %w", err)
 			}
 		}
 	}
 	return nil
 }
 
+// initGoMod runs "go mod init", or writes a go.mod without any requirements
+// when commands are skipped
+func (p *Project) initGoMod(ctx context.Context, projectPath string) error {
+	if !p.SkipCommands {
+		return gocmds.InitGoMod(ctx, p.runner(), p.ProjectName, projectPath)
+	}
+
+	goMod := fmt.Sprintf("module %s\n\ngo %s\n", p.ProjectName, goVersion())
+	return p.fs().WriteFile(filepath.Join(projectPath, "go.mod"), []byte(goMod), 0o644)
+}
+
+// goGetPackage adds the packages to go.mod. Without commands they are
+// left for "go mod tidy" to resolve in the generated project
+func (p *Project) goGetPackage(ctx context.Context, projectPath string, packages []string) error {
+	if p.SkipCommands {
+		return nil
+	}
+	return gocmds.GoGetPackage(ctx, p.runner(), projectPath, packages)
+}
+
+// goVersion returns the language version of the running toolchain, e.g. 1.25
+func goVersion() string {
+	parts := strings.SplitN(strings.TrimPrefix(runtime.Version(), "go"), ".", 3)
+	if len(parts) < 2 {
+		return defaultGoVersion
+	}
+
+	// Drop pre-release suffixes such as the "rc1" in "1.25rc1"
+	minor := parts[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minor = minor[:i]
+	}
+	if minor == "" {
+		return defaultGoVersion
+	}
+	return parts[0] + "." + minor
+}
+
+// MainPath returns the directory of the main package, cmd/api for a server
+// and cmd/<name> for a CLI
+func (p *Project) MainPath() string {
+	if p.ProjectType == flags.Cli {
+		return "cmd/" + p.CommandName()
+	}
+	return cmdApiPath
+}
+
+// CommandName returns the name of the built binary, the last element of
+// the module path
+func (p *Project) CommandName() string {
+	return modules.GetRootDir(p.ProjectName)
+}
+
+// createFrameworkFiles writes the files only the selected framework needs,
+// see registry.FilesTemplater
+func (p *Project) createFrameworkFiles(projectPath string) error {
+	templater, ok := p.FrameworkMap[p.ProjectType].templater.(registry.FilesTemplater)
+	if !ok {
+		return nil
+	}
+	return p.writeTemplates(projectPath, templater.Files())
+}
+
+// CreateWorker writes the worker binary and the job queue kept by the
+// selected driver, see registry.QueueTemplater
+func (p *Project) CreateWorker(projectPath string) error {
+	queue, ok := p.DBDriverMap[p.DBDriver].templater.(registry.QueueTemplater)
+	if !ok {
+		return fmt.Errorf("the %s driver cannot keep the job queue of the worker", p.DBDriver)
+	}
+
+	files := advanced.WorkerFiles()
+	files[internalDatabasePath+"/queue.go"] = queue.Queue()
+	files[internalDatabasePath+"/queue_test.go"] = queue.QueueTests()
+	return p.writeTemplates(projectPath, files)
+}
+
+// CreateConnectService writes the sample protobuf service with its Connect
+// handler code and the implementation mounted by RegisterRoutes
+func (p *Project) CreateConnectService(ctx context.Context, projectPath string) error {
+	connect, _ := registry.LookupFeature(flags.Connect)
+	err := p.goGetPackage(ctx, projectPath, connect.PackagesFor(p.ProjectType))
+	if err != nil {
+		return fmt.Errorf("could not install connect dependency: %w", err)
+	}
+
+	files := protobuf.Files()
+	maps.Copy(files, protobuf.ConnectFiles())
+	files[internalServerPath+"/greeter.go"] = advanced.ConnectServiceTemplate()
+	return p.writeTemplates(projectPath, files)
+}
+
+// writeTemplates writes every template of files, by slash separated path
+// relative to projectPath, creating the directories they are in
+func (p *Project) writeTemplates(projectPath string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		filePath := filepath.Join(projectPath, filepath.FromSlash(path))
+		if err := p.fs().MkdirAll(filepath.Dir(filePath), 0o751); err != nil {
+			return fmt.Errorf("error creating directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := p.writeTemplateIfNotEmpty(filePath, files[path]); err != nil {
+			return fmt.Errorf("error injecting %s file: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// writeFiles is writeTemplates for files that are written as they are
+func (p *Project) writeFiles(projectPath string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		filePath := filepath.Join(projectPath, filepath.FromSlash(path))
+		if err := p.fs().MkdirAll(filepath.Dir(filePath), 0o751); err != nil {
+			return fmt.Errorf("error creating directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := p.writeFile(filePath, files[path], 0o644); err != nil {
+			return fmt.Errorf("error writing %s file: %w", path, err)
+		}
+	}
+	return nil
+}
+
 // CreatePath creates the given directory in the projectPath
 func (p *Project) CreatePath(pathToCreate string, projectPath string) error {
 	path := filepath.Join(projectPath, pathToCreate)
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		err := os.MkdirAll(path, 0o751)
-		if err != nil {
-			log.Printf("Error creating directory %v\n", err)
-			return err
-		}
+	if err := p.fs().MkdirAll(path, 0o751); err != nil {
+		return fmt.Errorf("error creating directory %s: %w", pathToCreate, err)
 	}
 
 	return nil
@@ -573,270 +678,179 @@ func (p *Project) CreatePath(pathToCreate string, projectPath string) error {
 // CreateFileWithInjection creates the given file at the
 // project path, and injects the appropriate template
 func (p *Project) CreateFileWithInjection(pathToCreate string, projectPath string, fileName string, methodName string) error {
-	createdFile, err := os.Create(filepath.Join(projectPath, pathToCreate, fileName))
-	if err != nil {
-		return err
-	}
-
-	defer createdFile.Close()
+	var tmpl []byte
 
 	switch methodName {
 	case "main":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.Main())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.FrameworkMap[p.ProjectType].templater.Main()
 	case "server":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.Server())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.FrameworkMap[p.ProjectType].templater.Server()
 	case "routes":
-		routeFileBytes := p.FrameworkMap[p.ProjectType].templater.Routes()
-		createdTemplate := template.Must(template.New(fileName).Parse(string(routeFileBytes)))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.FrameworkMap[p.ProjectType].templater.Routes()
 	case "request":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.RequestPackage())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.FrameworkMap[p.ProjectType].templater.RequestPackage()
 	case "response":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.FrameworkMap[p.ProjectType].templater.ResponsePackage())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.FrameworkMap[p.ProjectType].templater.ResponsePackage()
 	case "releaser":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(advanced.Releaser())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = advanced.Releaser()
 	case "go-test":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(advanced.Test())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = advanced.Test()
 	case "releaser-config":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(advanced.ReleaserConfig())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = advanced.ReleaserConfig()
 	case "database":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.DBDriverMap[p.DBDriver].templater.Service())))
-		err = createdTemplate.Execute(createdFile, p)
-	case "db-docker":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.DockerMap[p.Docker].templater.Docker())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.DBDriverMap[p.DBDriver].templater.Service()
+	case "docker-compose":
+		tmpl = advanced.DockerCompose()
+		if p.Docker != "" {
+			tmpl = p.DockerMap[p.Docker].templater.Docker()
+		}
 	case "integration-tests":
-		createdTemplate := template.Must(template.New(fileName).Parse(string(p.DBDriverMap[p.DBDriver].templater.Tests())))
-		err = createdTemplate.Execute(createdFile, p)
+		tmpl = p.DBDriverMap[p.DBDriver].templater.Tests()
 	case "env":
+		tmpl = tpl.GlobalEnvTemplate()
 		if p.DBDriver != "none" {
-
 			envBytes := [][]byte{
 				tpl.GlobalEnvTemplate(),
 				p.DBDriverMap[p.DBDriver].templater.Env(),
 			}
-			createdTemplate := template.Must(template.New(fileName).Parse(string(bytes.Join(envBytes, []byte("\n")))))
-			err = createdTemplate.Execute(createdFile, p)
-
-		} else {
-			createdTemplate := template.Must(template.New(fileName).Parse(string(tpl.GlobalEnvTemplate())))
-			err = createdTemplate.Execute(createdFile, p)
+			tmpl = bytes.Join(envBytes, []byte("\n"))
 		}
 	}
 
+	return p.writeTemplate(filepath.Join(projectPath, pathToCreate, fileName), tmpl)
+}
+
+// writeTemplate executes the template with the project as its data and
+// writes the result to filePath
+func (p *Project) writeTemplate(filePath string, tmpl []byte) error {
+	data, err := p.renderTemplate(filePath, tmpl)
+	if err != nil {
+		return err
+	}
+
+	return p.writeFile(filePath, data, 0o644)
+}
+
+// writeTemplateIfNotEmpty is writeTemplate, except that nothing is written
+// when the template renders to white space only. A template may so leave
+// out its file for some selections
+func (p *Project) writeTemplateIfNotEmpty(filePath string, tmpl []byte) error {
+	data, err := p.renderTemplate(filePath, tmpl)
 	if err != nil {
 		return err
 	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
 
-	return nil
+	return p.writeFile(filePath, data, 0o644)
 }
 
-func (p *Project) CreateWebsocketImports(appDir string) {
-	websocketDependency := []string{"github.com/coder/websocket"}
-	if p.ProjectType == flags.Fiber {
-		websocketDependency = []string{"github.com/gofiber/contrib/websocket"}
+// renderTemplate executes the template of the file at filePath with the
+// project as its data
+func (p *Project) renderTemplate(filePath string, tmpl []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	createdTemplate := template.Must(template.New(filepath.Base(filePath)).Parse(string(tmpl)))
+	if err := createdTemplate.Execute(&buf, p); err != nil {
+		return nil, err
 	}
+	return buf.Bytes(), nil
+}
 
+// writeFile writes data to filePath. Go files are formatted in process when
+// gofmt will not be run on the project afterwards
+func (p *Project) writeFile(filePath string, data []byte, perm fs.FileMode) error {
+	if p.SkipCommands && filepath.Ext(filePath) == ".go" {
+		if formatted, err := format.Source(data); err == nil {
+			data = formatted
+		}
+	}
+
+	return p.fs().WriteFile(filePath, data, perm)
+}
+
+func (p *Project) CreateWebsocketImports(ctx context.Context, appDir string) error {
 	// Websockets require a different package depending on what framework is
 	// choosen. The application calls go mod tidy at the end so we don't
 	// have to here
-	err := gocmds.GoGetPackage(appDir, websocketDependency)
+	websocket, _ := registry.LookupFeature(flags.Websocket)
+	err := p.goGetPackage(ctx, appDir, websocket.PackagesFor(p.ProjectType))
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("could not install websocket dependency: %w", err)
 	}
 
 	importsPlaceHolder := string(p.FrameworkMap[p.ProjectType].templater.WebsocketImports())
 
 	importTmpl, err := template.New("imports").Parse(importsPlaceHolder)
 	if err != nil {
-		log.Fatalf("CreateWebsocketImports failed to create template: %v", err)
+		return fmt.Errorf("CreateWebsocketImports failed to create template: %w", err)
 	}
 	var importBuffer bytes.Buffer
 	err = importTmpl.Execute(&importBuffer, p)
 	if err != nil {
-		log.Fatalf("CreateWebsocketImports failed write template: %v", err)
+		return fmt.Errorf("CreateWebsocketImports failed write template: %w", err)
 	}
 	newImports := strings.Join([]string{string(p.AdvancedTemplates.TemplateImports), importBuffer.String()}, "\n")
 	p.AdvancedTemplates.TemplateImports = newImports
+	return nil
 }
 
-func (p *Project) CreateViteReactProject(projectPath string) error {
-	if err := checkNpmInstalled(); err != nil {
-		return err
-	}
-
-	originalDir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
-	defer func() {
-		if err := os.Chdir(originalDir); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to change back to original directory: %v\n", err)
+// Frontend returns the selected frontend feature, empty when the project
+// has none
+func (p *Project) Frontend() string {
+	for _, feature := range registry.Features {
+		if feature.Frontend != nil && p.AdvancedOptions[feature.Value] {
+			return feature.Value
 		}
-	}()
-
-	// change into the project directory to run vite command
-	err = os.Chdir(projectPath)
-	if err != nil {
-		fmt.Println("failed to change into project directory: %w", err)
 	}
+	return ""
+}
 
-	fmt.Println("Installing create-vite (using cache if available)...")
-	cmd := exec.Command("npm", "create", "vite@latest", "frontend", "--",
-		"--template", "react-ts",
-		"--prefer-offline",
-		"--no-fund")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to use create-vite: %w", err)
-	}
+// CreateFrontend writes the selected frontend to the frontend directory
+// from its embedded files, see registry.FrontendTemplater, and installs its
+// dependencies when InstallFrontend is set
+func (p *Project) CreateFrontend(ctx context.Context, projectPath string) error {
+	feature, _ := registry.LookupFeature(p.Frontend())
+	templater := feature.Frontend
 
 	frontendPath := filepath.Join(projectPath, "frontend")
-	if err := os.MkdirAll(frontendPath, 0755); err != nil {
+	if err := p.fs().MkdirAll(frontendPath, 0755); err != nil {
 		return fmt.Errorf("failed to create frontend directory: %w", err)
 	}
 
-	if err := os.Chdir(frontendPath); err != nil {
-		return fmt.Errorf("failed to change to frontend directory: %w", err)
-	}
-
-	srcDir := filepath.Join(frontendPath, "src")
-	if err := os.MkdirAll(srcDir, 0755); err != nil {
-		return fmt.Errorf("failed to create src directory: %w", err)
-	}
-
-	err = p.CreateFileWithInjection("", projectPath, ".env", "env")
+	err := p.CreateFileWithInjection("", projectPath, ".env", "env")
 	if err != nil {
 		return fmt.Errorf("failed to create global .env file: %w", err)
 	}
 
-	// Read from the global `.env` file and create the frontend-specific `.env`
-	globalEnvPath := filepath.Join(projectPath, ".env")
-	vitePort := "8080" // Default fallback
-
-	// Read the global .env file
-	if data, err := os.ReadFile(globalEnvPath); err == nil {
-		lines := strings.Split(string(data), "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "PORT=") {
-				vitePort = strings.SplitN(line, "=", 2)[1] // Get the backend port value
-				break
-			}
-		}
-	}
-
-	// Use a template to generate the frontend .env file
-	frontendEnvContent := fmt.Sprintf("VITE_PORT=%s\n", vitePort)
-	if err := os.WriteFile(filepath.Join(frontendPath, ".env"), []byte(frontendEnvContent), 0644); err != nil {
+	// The frontend calls the Go API on the port of the global .env
+	frontendEnvContent := fmt.Sprintf("VITE_PORT=%d\n", p.Vars.Port)
+	if err := p.fs().WriteFile(filepath.Join(frontendPath, ".env"), []byte(frontendEnvContent), 0644); err != nil {
 		return fmt.Errorf("failed to create frontend .env file: %w", err)
 	}
 
-	if err := os.WriteFile(filepath.Join(frontendPath, "package.json"), advanced.ReactPackageJsonFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write package.json template: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(frontendPath, "tsconfig.json"), advanced.ReactTsConfigJsonFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write tsconfig.json template: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(frontendPath, "tsconfig.app.json"), advanced.ReactTsConfigAppJsonFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write tsconfig.app.json template: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(frontendPath, "vite.config.ts"), advanced.ReactViteConfigFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write main.tsx template: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(srcDir, "main.tsx"), advanced.ReactMainFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write main.tsx template: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(srcDir, "styles.css"), advanced.ReactStylesCssFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write main.tsx template: %w", err)
-	}
-
-	// Create additional directories for TanStack Router and utilities
-	routesDir := filepath.Join(srcDir, "routes")
-	if err := os.MkdirAll(routesDir, 0755); err != nil {
-		return fmt.Errorf("failed to create src/routes directory: %w", err)
-	}
-
-	libDir := filepath.Join(srcDir, "lib")
-	if err := os.MkdirAll(libDir, 0755); err != nil {
-		return fmt.Errorf("failed to create src/lib directory: %w", err)
-	}
-
-	// Write TanStack Router root route and utils helper
-	if err := os.WriteFile(filepath.Join(routesDir, "__root.tsx"), advanced.ReactRootRouteFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write routes/root.tsx template: %w", err)
-	}
-
-	// Write TanStack Router root route and utils helper
-	if err := os.WriteFile(filepath.Join(routesDir, "index.tsx"), advanced.ReactIndexRouteFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write routes/index.tsx template: %w", err)
-	}
-
-	// Write TanStack Router root route and utils helper
-	if err := os.WriteFile(filepath.Join(routesDir, "demo.tanstack-query.tsx"), advanced.ReactDemoTanstackQueryRouteFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write routes/demo.tanstack-query.tsx template: %w", err)
-	}
-
-	componentsDir := filepath.Join(srcDir, "components")
-	if err := os.MkdirAll(componentsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create src/components directory: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(componentsDir, "Header.tsx"), advanced.ReactHeaderComponentFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write Header.tsx template: %w", err)
-	}
-
-	if err := os.WriteFile(filepath.Join(libDir, "utils.ts"), advanced.ReactUtilsFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write utils.ts template: %w", err)
-	}
-
-	// Write shadcn components.json for future UI generation
-	if err := os.WriteFile(filepath.Join(frontendPath, "components.json"), advanced.ReactComponentsJsonFile(), 0644); err != nil {
-		return fmt.Errorf("failed to write components.json template: %w", err)
+	if err := p.writeTemplates(frontendPath, templater.Templates()); err != nil {
+		return err
 	}
-
-	if err := os.Remove(filepath.Join(srcDir, "index.css")); err != nil {
-		// Don't return error if file doesn't exist
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to remove index.css: %w", err)
-		}
+	if err := p.writeFiles(frontendPath, templater.Files()); err != nil {
+		return err
 	}
 
-	if err := os.Remove(filepath.Join(srcDir, "App.css")); err != nil {
-		// Don't return error if file doesn't exist
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to remove App.css: %w", err)
+	if p.InstallFrontend && !p.SkipCommands {
+		if err := checkPackageManagerInstalled(ctx, p.runner(), p.PackageManager); err != nil {
+			return err
 		}
-	}
-
-	if err := os.Remove(filepath.Join(srcDir, "App.tsx")); err != nil {
-		// Don't return error if file doesn't exist
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to remove App.tsx: %w", err)
+		if err := p.runner().Run(ctx, p.PackageManager.String(), []string{"install"}, frontendPath); err != nil {
+			return fmt.Errorf("failed to install the frontend dependencies: %w", err)
 		}
 	}
 
 	return nil
 }
 
-func checkNpmInstalled() error {
-	cmd := exec.Command("npm", "--version")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("npm is not installed: %w", err)
+func checkPackageManagerInstalled(ctx context.Context, runner executor.CommandRunner, packageManager flags.PackageManager) error {
+	if err := runner.Run(ctx, packageManager.String(), []string{"--version"}, ""); err != nil {
+		return fmt.Errorf("%s is not installed: %w", packageManager, err)
 	}
 	return nil
 }
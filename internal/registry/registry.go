@@ -0,0 +1,488 @@
+// Package registry is the single list of the frameworks, database drivers,
+// advanced features and git options gofast knows about. The allowed flag
+// values, the wizard steps, the shell completions and the templates used
+// for generation are all derived from it, so adding a framework only takes
+// a new entry in Frameworks.
+package registry
+
+import (
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/template/dbdriver"
+	"github.com/mahibulhaque/gofast/internal/template/docker"
+	"github.com/mahibulhaque/gofast/internal/template/framework"
+	"github.com/mahibulhaque/gofast/internal/template/frontend"
+)
+
+// Templater provides the templates of a framework. Every template but Main
+// may be nil when the framework has no use for it, e.g. a CLI has no server
+type Templater interface {
+	Main() []byte
+	Server() []byte
+	Routes() []byte
+	WebsocketImports() []byte
+	RequestPackage() []byte
+	ResponsePackage() []byte
+}
+
+// FilesTemplater is implemented by the templaters of frameworks that need
+// files beyond the ones of every project
+type FilesTemplater interface {
+	// Files maps the slash separated path of each file, relative to the
+	// project root, to its template
+	Files() map[string][]byte
+}
+
+type DBDriverTemplater interface {
+	Service() []byte
+	Env() []byte
+	Tests() []byte
+}
+
+// QueueTemplater is implemented by the templaters of drivers that can keep
+// the job queue of the worker feature
+type QueueTemplater interface {
+	// Queue is the queue, in the database package
+	Queue() []byte
+	// QueueTests are the integration tests of the queue and the worker
+	QueueTests() []byte
+}
+
+type DockerTemplater interface {
+	Docker() []byte
+}
+
+// FrontendTemplater provides every file of a frontend, written to the
+// frontend directory. Paths are slash separated and relative to that directory
+type FrontendTemplater interface {
+	// Templates are executed with the project as data
+	Templates() map[string][]byte
+	// Files are written as they are
+	Files() map[string][]byte
+}
+
+// Framework describes an HTTP framework, or another kind of server, a
+// project can be built on
+type Framework struct {
+	Value       flags.Framework
+	Title       string
+	Description string
+	// Packages are installed with go get into the new project
+	Packages  []string
+	Templater Templater
+}
+
+// Driver describes a database driver that can be wired into a project
+type Driver struct {
+	Value       flags.Database
+	Title       string
+	Description string
+	// Packages are installed with go get into the new project
+	Packages []string
+	// Templater is nil when the driver adds no code, i.e. for none
+	Templater DBDriverTemplater
+	// Docker is nil when the database needs no container
+	Docker DockerTemplater
+}
+
+// Feature describes an advanced feature and the rules deciding whether it
+// can be generated, see Resolve
+type Feature struct {
+	Value       string
+	Title       string
+	Description string
+	// Packages are installed with go get when the feature is selected
+	Packages []string
+	// FrameworkPackages replace Packages for the given frameworks
+	FrameworkPackages map[flags.Framework][]string
+	// Tools must be installed to generate the feature
+	Tools []Tool
+	// Implies lists the features that are added along with this one. An
+	// implied frontend stands for any frontend, it is only added when no
+	// other frontend is selected
+	Implies []string
+	// Conflicts lists the selections the feature cannot be combined with
+	Conflicts []Conflict
+	// Notes are reported when their condition matches, without stopping
+	// the generation
+	Notes []Note
+	// Frontend is set for the frontend frameworks, a project has at most one
+	Frontend FrontendTemplater
+}
+
+// GitOption describes what is done with the git repository of a project
+type GitOption struct {
+	Value       flags.Git
+	Title       string
+	Description string
+}
+
+// Frameworks are listed in the order they are shown to the user
+var Frameworks = []Framework{
+	{
+		Value:       flags.StandardLibrary,
+		Title:       "Standard-library",
+		Description: "The built-in Go standard library HTTP package",
+		Templater:   framework.StandardLibTemplate{},
+	},
+	{
+		Value:       flags.Chi,
+		Title:       "Chi",
+		Description: "A lightweight, idiomatic and composable router for building Go HTTP services",
+		Packages:    []string{"github.com/go-chi/chi/v5"},
+		Templater:   framework.ChiTemplates{},
+	},
+	{
+		Value:       flags.Gin,
+		Title:       "Gin",
+		Description: "Features a martini-like API with performance that is up to 40 times faster thanks to httprouter",
+		Packages:    []string{"github.com/gin-gonic/gin"},
+		Templater:   framework.GinTemplates{},
+	},
+	{
+		Value:       flags.Fiber,
+		Title:       "Fiber",
+		Description: "An Express inspired web framework built on top of Fasthttp",
+		Packages:    []string{"github.com/gofiber/fiber/v2"},
+		Templater:   framework.FiberTemplates{},
+	},
+	{
+		Value:       flags.GorillaMux,
+		Title:       "Gorilla/Mux",
+		Description: "Package gorilla/mux implements a request router and dispatcher for matching incoming requests to their respective handler",
+		Packages:    []string{"github.com/gorilla/mux"},
+		Templater:   framework.GorillaTemplates{},
+	},
+	{
+		Value:       flags.HttpRouter,
+		Title:       "HttpRouter",
+		Description: "HttpRouter is a lightweight high performance HTTP request router for Go",
+		Packages:    []string{"github.com/julienschmidt/httprouter"},
+		Templater:   framework.RouterTemplates{},
+	},
+	{
+		Value:       flags.Echo,
+		Title:       "Echo",
+		Description: "High performance, extensible, minimalist Go web framework",
+		Packages:    []string{"github.com/labstack/echo/v4", "github.com/labstack/echo/v4/middleware"},
+		Templater:   framework.EchoTemplates{},
+	},
+	{
+		Value:       flags.Grpc,
+		Title:       "gRPC",
+		Description: "A gRPC service with protobuf generation, health checking and reflection",
+		Packages:    []string{"google.golang.org/grpc", "google.golang.org/protobuf"},
+		Templater:   framework.GrpcTemplates{},
+	},
+	{
+		Value:       flags.Cli,
+		Title:       "CLI",
+		Description: "A command line application built with Cobra, with version info, a config file and shell completion",
+		Packages:    []string{"github.com/spf13/cobra"},
+		Templater:   framework.CliTemplates{},
+	},
+}
+
+// Drivers are listed in the order they are shown to the user
+var Drivers = []Driver{
+	{
+		Value:       flags.MySql,
+		Title:       "Mysql",
+		Description: "MySQL-Driver for Go's database/sql package",
+		Packages:    []string{"github.com/go-sql-driver/mysql"},
+		Templater:   dbdriver.MysqlTemplate{},
+		Docker:      docker.MysqlDockerTemplate{},
+	},
+	{
+		Value:       flags.Postgres,
+		Title:       "Postgres",
+		Description: "Go postgres driver for Go's database/sql package",
+		Packages:    []string{"github.com/jackc/pgx/v5/stdlib"},
+		Templater:   dbdriver.PostgresTemplate{},
+		Docker:      docker.PostgresDockerTemplate{},
+	},
+	{
+		Value:       flags.Sqlite,
+		Title:       "Sqlite",
+		Description: "sqlite3 driver conforming to the built-in database/sql interface",
+		Packages:    []string{"github.com/mattn/go-sqlite3"},
+		Templater:   dbdriver.SqliteTemplate{},
+	},
+	{
+		Value:       flags.Mongo,
+		Title:       "Mongo",
+		Description: "The MongoDB supported driver for Go.",
+		Packages:    []string{"go.mongodb.org/mongo-driver"},
+		Templater:   dbdriver.MongoTemplate{},
+		Docker:      docker.MongoDockerTemplate{},
+	},
+	{
+		Value:       flags.Redis,
+		Title:       "Redis",
+		Description: "Redis driver for Go.",
+		Packages:    []string{"github.com/redis/go-redis/v9"},
+		Templater:   dbdriver.RedisTemplate{},
+		Docker:      docker.RedisDockerTemplate{},
+	},
+	{
+		Value:       flags.None,
+		Title:       "None",
+		Description: "Choose this option if you don't wish to install a specific database driver.",
+	},
+}
+
+// frontendTools install and run every frontend, they are only needed to
+// generate it when Selection.InstallFrontend is set
+var frontendTools = []Tool{
+	{Name: "npm", Install: "install Node.js from https://nodejs.org"},
+}
+
+// frontendConflicts are shared by every frontend
+var frontendConflicts = []Conflict{
+	{
+		When:   Condition{Framework: flags.Grpc},
+		Reason: "a gRPC service does not serve the frontend",
+	},
+	{
+		When:   Condition{Framework: flags.Cli},
+		Reason: "a CLI does not serve the frontend",
+	},
+}
+
+// Features are listed in the order they are shown to the user
+var Features = []Feature{
+	{
+		Value:       flags.React,
+		Title:       "React",
+		Description: "Use Vite to spin up a React project in TypeScript.",
+		Tools:       frontendTools,
+		Conflicts:   frontendConflicts,
+		Frontend:    frontend.ReactTemplate{},
+	},
+	{
+		Value:       flags.Vue,
+		Title:       "Vue",
+		Description: "Use Vite to spin up a Vue project in TypeScript.",
+		Tools:       frontendTools,
+		Conflicts:   frontendConflicts,
+		Frontend:    frontend.VueTemplate{},
+	},
+	{
+		Value:       flags.Svelte,
+		Title:       "Svelte",
+		Description: "Use Vite to spin up a Svelte project in TypeScript.",
+		Tools:       frontendTools,
+		Conflicts:   frontendConflicts,
+		Frontend:    frontend.SvelteTemplate{},
+	},
+	{
+		Value:       flags.Solid,
+		Title:       "Solid",
+		Description: "Use Vite to spin up a Solid project in TypeScript.",
+		Tools:       frontendTools,
+		Conflicts:   frontendConflicts,
+		Frontend:    frontend.SolidTemplate{},
+	},
+	{
+		Value:       flags.GoProjectWorkflow,
+		Title:       "Go Project Workflow",
+		Description: "Workflow templates for testing, cross-compiling and releasing Go projects",
+	},
+	{
+		Value:       flags.Websocket,
+		Title:       "Websocket endpoint",
+		Description: "Add a websocket endpoint",
+		Packages:    []string{"github.com/coder/websocket"},
+		FrameworkPackages: map[flags.Framework][]string{
+			flags.Fiber: {"github.com/gofiber/contrib/websocket"},
+		},
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Framework: flags.Grpc},
+				Reason: "a gRPC service has no HTTP routes, use a streaming RPC instead",
+			},
+			{
+				When:   Condition{Framework: flags.Cli},
+				Reason: "a CLI has no HTTP routes",
+			},
+		},
+		Notes: []Note{
+			{
+				When:    Condition{Framework: flags.Fiber},
+				Message: "Fiber is not based on net/http, the websocket endpoint uses github.com/gofiber/contrib/websocket instead of github.com/coder/websocket",
+			},
+		},
+	},
+	{
+		Value:       flags.Docker,
+		Title:       "Docker",
+		Description: "Dockerfile and docker-compose generic configuration for go project",
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Framework: flags.Cli},
+				Reason: "the Dockerfile runs a server, a CLI is released as binaries with the Go Project Workflow",
+			},
+		},
+		Notes: []Note{
+			{
+				When:    Condition{Driver: flags.Sqlite},
+				Message: "go-sqlite3 uses cgo, so the Dockerfile installs alpine-sdk and builds with CGO_ENABLED=1. The database file is kept in the sqlite volume",
+			},
+		},
+	},
+	{
+		Value:       flags.Connect,
+		Title:       "Connect RPC",
+		Description: "A protobuf service served by Connect in the HTTP router, answering JSON over HTTP, gRPC and gRPC-Web",
+		Packages:    []string{"connectrpc.com/connect", "google.golang.org/protobuf"},
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Framework: flags.Grpc},
+				Reason: "the gRPC framework already serves the services in proto/",
+			},
+			{
+				When:   Condition{Framework: flags.Cli},
+				Reason: "a CLI has no HTTP router to mount the Connect handler in",
+			},
+		},
+		Notes: []Note{
+			{
+				When:    Condition{Framework: flags.Fiber},
+				Message: "Fiber is not based on net/http and only speaks HTTP/1.1, the Connect handler is mounted with the fiber adaptor and answers Connect and gRPC-Web clients but not gRPC clients",
+			},
+		},
+	},
+	{
+		Value:       flags.GraphQL,
+		Title:       "GraphQL",
+		Description: "A schema-first GraphQL API generated by gqlgen, served on /graphql with a playground",
+		// The version matches the code generated from the schema, which is
+		// included in the template
+		Packages: []string{"github.com/99designs/gqlgen@v0.17.81"},
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Framework: flags.Grpc},
+				Reason: "a gRPC service has no HTTP routes to serve /graphql",
+			},
+			{
+				When:   Condition{Framework: flags.Cli},
+				Reason: "a CLI has no HTTP routes to serve /graphql",
+			},
+		},
+	},
+	{
+		Value:       flags.Worker,
+		Title:       "Background worker",
+		Description: "A cmd/worker binary processing jobs of a queue kept in Postgres or Redis, with retries and a graceful drain",
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Driver: flags.None},
+				Reason: "the job queue is kept in the database, choose postgres or redis",
+			},
+			{
+				When:   Condition{Driver: flags.MySql},
+				Reason: "the job queue is only implemented for postgres and redis",
+			},
+			{
+				When:   Condition{Driver: flags.Sqlite},
+				Reason: "the job queue is only implemented for postgres and redis",
+			},
+			{
+				When:   Condition{Driver: flags.Mongo},
+				Reason: "the job queue is only implemented for postgres and redis",
+			},
+		},
+	},
+	{
+		Value:       flags.Web,
+		Title:       "Web binary",
+		Description: "A cmd/web binary serving the built frontend and server-rendered pages, separate from the JSON API",
+		Implies:     []string{flags.React},
+	},
+	{
+		Value:       flags.Embed,
+		Title:       "Embedded frontend",
+		Description: "Embed the built frontend into the API server for single binary deploys, proxied to Vite in development",
+		Implies:     []string{flags.React},
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Feature: flags.Web},
+				Reason: "the web binary already serves the frontend",
+			},
+		},
+	},
+	{
+		Value:       flags.Htmx,
+		Title:       "HTMX",
+		Description: "Server-rendered pages built from templ components and layouts, styled with Tailwind and updated with HTMX",
+		// The version matches the code generated from the components, which
+		// is included in the template
+		Packages: []string{"github.com/a-h/templ@v0.3.977"},
+		Conflicts: []Conflict{
+			{
+				When:   Condition{Framework: flags.Grpc},
+				Reason: "a gRPC service has no HTTP routes to serve the pages",
+			},
+			{
+				When:   Condition{Framework: flags.Cli},
+				Reason: "a CLI has no HTTP routes to serve the pages",
+			},
+		},
+	},
+}
+
+// GitOptions are listed in the order they are shown to the user
+var GitOptions = []GitOption{
+	{
+		Value:       flags.Commit,
+		Title:       "Commit",
+		Description: "Initialize a new git repository and commit all the changes",
+	},
+	{
+		Value:       flags.Stage,
+		Title:       "Stage",
+		Description: "Initialize a new git repository but only stage the changes",
+	},
+	{
+		Value:       flags.Skip,
+		Title:       "Skip",
+		Description: "Proceed without initializing a git repository",
+	},
+}
+
+// LookupFramework returns the registered framework with the given value
+func LookupFramework(value flags.Framework) (Framework, bool) {
+	for _, f := range Frameworks {
+		if f.Value == value {
+			return f, true
+		}
+	}
+	return Framework{}, false
+}
+
+// LookupFeature returns the registered feature with the given value
+func LookupFeature(value string) (Feature, bool) {
+	for _, f := range Features {
+		if f.Value == value {
+			return f, true
+		}
+	}
+	return Feature{}, false
+}
+
+// PackagesFor returns the packages the feature needs with the given framework
+func (f Feature) PackagesFor(framework flags.Framework) []string {
+	if packages, ok := f.FrameworkPackages[framework]; ok {
+		return packages
+	}
+	return f.Packages
+}
+
+// LookupDriver returns the registered driver with the given value
+func LookupDriver(value flags.Database) (Driver, bool) {
+	for _, d := range Drivers {
+		if d.Value == value {
+			return d, true
+		}
+	}
+	return Driver{}, false
+}
@@ -0,0 +1,279 @@
+package registry
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/flags"
+)
+
+// Tool is an executable a feature needs while the project is generated
+type Tool struct {
+	Name string
+	// Install tells the user how to get the tool
+	Install string
+}
+
+// Condition matches a selection. Every field that is set must match, an
+// empty condition matches any selection
+type Condition struct {
+	Framework flags.Framework
+	Driver    flags.Database
+	Feature   string
+}
+
+// Conflict forbids combining a feature with the selections matching When
+type Conflict struct {
+	When   Condition
+	Reason string
+}
+
+// Note explains how a feature behaves in the selections matching When
+type Note struct {
+	When    Condition
+	Message string
+}
+
+// Selection is what is about to be generated
+type Selection struct {
+	Framework flags.Framework
+	Driver    flags.Database
+	Features  []string
+	Git       flags.Git
+	// PackageManager takes the place of npm in the tools of the features,
+	// npm when empty
+	PackageManager flags.PackageManager
+	// SkipCommands is set when no external command is run, so no tool
+	// is needed
+	SkipCommands bool
+	// InstallFrontend is set when the dependencies of the frontend are
+	// installed once it is generated
+	InstallFrontend bool
+}
+
+// Resolution is a selection that passed every rule
+type Resolution struct {
+	// Features holds the selected and implied features, in registry order
+	Features []string
+	// Notes are worth showing to the user but need no action
+	Notes []string
+}
+
+// Problem is a rule a selection breaks
+type Problem struct {
+	Feature string
+	Message string
+	// Fix tells the user what to change
+	Fix string
+}
+
+// ResolveError lists every problem of a selection
+type ResolveError struct {
+	Problems []Problem
+}
+
+func (e *ResolveError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		lines[i] = fmt.Sprintf("  %s: %s", problem.Message, problem.Fix)
+	}
+	return "the selected features cannot be generated:\n" + strings.Join(lines, "\n")
+}
+
+// LookPath finds the tools features depend on, it may be replaced to check
+// another environment
+var LookPath = exec.LookPath
+
+// ToolFor returns tool, or the package manager of sel in place of npm
+func (sel Selection) ToolFor(tool Tool) Tool {
+	if tool.Name != flags.Npm.String() || sel.PackageManager == "" || sel.PackageManager == flags.Npm {
+		return tool
+	}
+	return Tool{
+		Name:    sel.PackageManager.String(),
+		Install: fmt.Sprintf("install %s or set package_manager to another package manager", sel.PackageManager),
+	}
+}
+
+// NeedsTools reports whether the tools of feature are run to generate sel.
+// A frontend is written from embedded files, its package manager is only
+// run when InstallFrontend is set
+func (sel Selection) NeedsTools(feature Feature) bool {
+	if sel.SkipCommands {
+		return false
+	}
+	return feature.Frontend == nil || sel.InstallFrontend
+}
+
+func (c Condition) matches(sel Selection, features map[string]bool) bool {
+	if c.Framework != "" && c.Framework != sel.Framework {
+		return false
+	}
+	if c.Driver != "" && c.Driver != sel.Driver {
+		return false
+	}
+	if c.Feature != "" && !features[c.Feature] {
+		return false
+	}
+	return true
+}
+
+// describe names what the condition matches, e.g. "--driver sqlite"
+func (c Condition) describe() string {
+	var parts []string
+	if c.Framework != "" {
+		parts = append(parts, "--framework "+c.Framework.String())
+	}
+	if c.Driver != "" {
+		parts = append(parts, "--driver "+c.Driver.String())
+	}
+	if c.Feature != "" {
+		parts = append(parts, "--feature "+c.Feature)
+	}
+	return strings.Join(parts, " and ")
+}
+
+// Resolve adds the implied features to the selection and checks the
+// requirements and conflicts of every feature. All problems are reported
+// at once in a *ResolveError.
+func Resolve(sel Selection) (Resolution, error) {
+	selected := make(map[string]bool)
+	var problems []Problem
+
+	for _, value := range sel.Features {
+		if _, ok := LookupFeature(value); !ok {
+			problems = append(problems, Problem{
+				Feature: value,
+				Message: fmt.Sprintf("unknown feature %s", value),
+				Fix:     fmt.Sprintf("use one of %s", strings.Join(FeatureValues(), ", ")),
+			})
+			continue
+		}
+		selected[value] = true
+	}
+
+	// Implied features may imply further features. A frontend is only
+	// implied when no other frontend is selected
+	var notes []string
+	for added := true; added; {
+		added = false
+		for _, feature := range Features {
+			if !selected[feature.Value] {
+				continue
+			}
+			for _, implied := range feature.Implies {
+				if selected[implied] {
+					continue
+				}
+				reason := "requires it"
+				if impliedFeature, _ := LookupFeature(implied); impliedFeature.Frontend != nil {
+					if selectedFrontend(selected) != "" {
+						continue
+					}
+					reason = "needs a frontend"
+				}
+				selected[implied] = true
+				added = true
+				notes = append(notes, fmt.Sprintf("%s was added because %s %s", implied, feature.Value, reason))
+			}
+		}
+	}
+
+	// A project has at most one frontend
+	var frontends []string
+	for _, feature := range Features {
+		if selected[feature.Value] && feature.Frontend != nil {
+			frontends = append(frontends, feature.Value)
+		}
+	}
+	if len(frontends) > 1 {
+		problems = append(problems, Problem{
+			Feature: frontends[1],
+			Message: fmt.Sprintf("only one frontend can be generated, %s were selected", strings.Join(frontends, " and ")),
+			Fix:     fmt.Sprintf("keep only one of --feature %s", strings.Join(frontends, ", --feature ")),
+		})
+	}
+
+	var resolution Resolution
+	for _, feature := range Features {
+		if !selected[feature.Value] {
+			continue
+		}
+		resolution.Features = append(resolution.Features, feature.Value)
+
+		for _, conflict := range feature.Conflicts {
+			if conflict.When.matches(sel, selected) {
+				problems = append(problems, Problem{
+					Feature: feature.Value,
+					Message: fmt.Sprintf("%s cannot be combined with %s, %s", feature.Value, conflict.When.describe(), conflict.Reason),
+					Fix:     fmt.Sprintf("remove --feature %s or change %s", feature.Value, conflict.When.describe()),
+				})
+			}
+		}
+
+		if sel.NeedsTools(feature) {
+			for _, tool := range feature.Tools {
+				tool = sel.ToolFor(tool)
+				if _, err := LookPath(tool.Name); err != nil {
+					problems = append(problems, Problem{
+						Feature: feature.Value,
+						Message: fmt.Sprintf("%s requires %s, which was not found in PATH", feature.Value, tool.Name),
+						Fix:     fmt.Sprintf("%s or remove --feature %s", tool.Install, feature.Value),
+					})
+				}
+			}
+		}
+
+		for _, note := range feature.Notes {
+			if note.When.matches(sel, selected) {
+				notes = append(notes, note.Message)
+			}
+		}
+	}
+	resolution.Notes = notes
+
+	if len(problems) > 0 {
+		return resolution, &ResolveError{Problems: problems}
+	}
+	return resolution, nil
+}
+
+// selectedFrontend returns the first selected frontend, empty when there
+// is none
+func selectedFrontend(selected map[string]bool) string {
+	for _, feature := range Features {
+		if selected[feature.Value] && feature.Frontend != nil {
+			return feature.Value
+		}
+	}
+	return ""
+}
+
+// Unavailable returns, by feature, why it cannot be selected with the
+// framework and driver of sel. Conflicts between features are left to
+// Resolve, as they depend on the other features chosen.
+func Unavailable(sel Selection) map[string]string {
+	reasons := make(map[string]string)
+
+	for _, feature := range Features {
+		for _, conflict := range feature.Conflicts {
+			if conflict.When.Feature == "" && conflict.When.matches(sel, nil) {
+				reasons[feature.Value] = fmt.Sprintf("Not available with %s, %s", conflict.When.describe(), conflict.Reason)
+				break
+			}
+		}
+		if _, ok := reasons[feature.Value]; ok || !sel.NeedsTools(feature) {
+			continue
+		}
+		for _, tool := range feature.Tools {
+			tool = sel.ToolFor(tool)
+			if _, err := LookPath(tool.Name); err != nil {
+				reasons[feature.Value] = fmt.Sprintf("Requires %s, %s", tool.Name, tool.Install)
+				break
+			}
+		}
+	}
+
+	return reasons
+}
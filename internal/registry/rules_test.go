@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mahibulhaque/gofast/internal/flags"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		sel      Selection
+		features []string
+		notes    int
+		// problems are the features of the expected problems, in order
+		problems []string
+	}{
+		{
+			name: "nothing selected",
+			sel:  Selection{Framework: flags.Chi, Driver: flags.None},
+		},
+		{
+			name:     "web implies react",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.Web}},
+			features: []string{flags.React, flags.Web},
+			notes:    1,
+		},
+		{
+			name:     "embed keeps the chosen frontend",
+			sel:      Selection{Framework: flags.Gin, Driver: flags.None, Features: []string{flags.Embed, flags.Vue}},
+			features: []string{flags.Vue, flags.Embed},
+		},
+		{
+			name:     "embed conflicts with web",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.Web, flags.Embed}},
+			features: []string{flags.React, flags.Web, flags.Embed},
+			notes:    1,
+			problems: []string{flags.Embed},
+		},
+		{
+			name:     "one frontend only",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.Vue, flags.Solid}},
+			features: []string{flags.Vue, flags.Solid},
+			problems: []string{flags.Solid},
+		},
+		{
+			name:     "frontend with grpc",
+			sel:      Selection{Framework: flags.Grpc, Driver: flags.None, Features: []string{flags.Svelte}},
+			features: []string{flags.Svelte},
+			problems: []string{flags.Svelte},
+		},
+		{
+			name:     "htmx with cli",
+			sel:      Selection{Framework: flags.Cli, Driver: flags.None, Features: []string{flags.Htmx}},
+			features: []string{flags.Htmx},
+			problems: []string{flags.Htmx},
+		},
+		{
+			name:     "worker with sqlite",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.Sqlite, Features: []string{flags.Worker}},
+			features: []string{flags.Worker},
+			problems: []string{flags.Worker},
+		},
+		{
+			name:     "worker with postgres",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.Postgres, Features: []string{flags.Worker}},
+			features: []string{flags.Worker},
+		},
+		{
+			name:     "unknown feature",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{"nope"}},
+			problems: []string{"nope"},
+		},
+		{
+			name:     "frontend without npm",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.React}},
+			features: []string{flags.React},
+		},
+		{
+			name:     "frontend installed without npm",
+			sel:      Selection{Framework: flags.Chi, Driver: flags.None, Features: []string{flags.React}, InstallFrontend: true},
+			features: []string{flags.React},
+			problems: []string{flags.React},
+		},
+	}
+
+	lookPath := LookPath
+	LookPath = func(string) (string, error) { return "", errors.New("not found") }
+	t.Cleanup(func() { LookPath = lookPath })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, err := Resolve(tt.sel)
+
+			if !reflect.DeepEqual(resolution.Features, tt.features) {
+				t.Errorf("features = %v, want %v", resolution.Features, tt.features)
+			}
+			if len(resolution.Notes) != tt.notes {
+				t.Errorf("notes = %q, want %d", resolution.Notes, tt.notes)
+			}
+
+			var problems []string
+			var resolveErr *ResolveError
+			if errors.As(err, &resolveErr) {
+				for _, problem := range resolveErr.Problems {
+					problems = append(problems, problem.Feature)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("problems = %v, want %v (%v)", problems, tt.problems, err)
+			}
+		})
+	}
+}
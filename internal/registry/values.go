@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/flags"
+)
+
+// FrameworkValues returns the value of every framework, in registry order
+func FrameworkValues() []string {
+	values := make([]string, len(Frameworks))
+	for i, f := range Frameworks {
+		values[i] = f.Value.String()
+	}
+	return values
+}
+
+// DriverValues returns the value of every database driver, in registry order
+func DriverValues() []string {
+	values := make([]string, len(Drivers))
+	for i, d := range Drivers {
+		values[i] = d.Value.String()
+	}
+	return values
+}
+
+// FeatureValues returns the value of every feature, in registry order
+func FeatureValues() []string {
+	values := make([]string, len(Features))
+	for i, f := range Features {
+		values[i] = f.Value
+	}
+	return values
+}
+
+// GitValues returns the value of every git option, in registry order
+func GitValues() []string {
+	values := make([]string, len(GitOptions))
+	for i, g := range GitOptions {
+		values[i] = g.Value.String()
+	}
+	return values
+}
+
+// ParseFramework returns the registered framework with the given value
+func ParseFramework(value string) (flags.Framework, error) {
+	if _, ok := LookupFramework(flags.Framework(value)); !ok {
+		return "", fmt.Errorf("Framework to use. Allowed values: %s", strings.Join(FrameworkValues(), ", "))
+	}
+	return flags.Framework(value), nil
+}
+
+// ParseDriver returns the registered database driver with the given value
+func ParseDriver(value string) (flags.Database, error) {
+	if _, ok := LookupDriver(flags.Database(value)); !ok {
+		return "", fmt.Errorf("Database to use. Allowed values: %s", strings.Join(DriverValues(), ", "))
+	}
+	return flags.Database(value), nil
+}
+
+// ParseFeature returns the registered feature with the given value
+func ParseFeature(value string) (string, error) {
+	if _, ok := LookupFeature(value); !ok {
+		return "", fmt.Errorf("advanced Feature to use. Allowed values: %s", strings.Join(FeatureValues(), ", "))
+	}
+	return value, nil
+}
+
+// ParseGit returns the registered git option with the given value
+func ParseGit(value string) (flags.Git, error) {
+	if !slices.Contains(GitValues(), value) {
+		return "", fmt.Errorf("Git to use. Allowed values: %s", strings.Join(GitValues(), ", "))
+	}
+	return flags.Git(value), nil
+}
+
+// Value is a command line flag value, as defined by pflag
+type Value interface {
+	String() string
+	Set(value string) error
+	Type() string
+}
+
+// checkedValue sets the wrapped flag value once parse accepts the value
+type checkedValue struct {
+	Value
+	parse func(value string) error
+}
+
+func (v checkedValue) Set(value string) error {
+	if err := v.parse(value); err != nil {
+		return err
+	}
+	return v.Value.Set(value)
+}
+
+// FrameworkFlag returns f as a flag value accepting the registered frameworks
+func FrameworkFlag(f *flags.Framework) Value {
+	return checkedValue{Value: f, parse: func(value string) error {
+		_, err := ParseFramework(value)
+		return err
+	}}
+}
+
+// DriverFlag returns d as a flag value accepting the registered drivers
+func DriverFlag(d *flags.Database) Value {
+	return checkedValue{Value: d, parse: func(value string) error {
+		_, err := ParseDriver(value)
+		return err
+	}}
+}
+
+// FeaturesFlag returns f as a repeatable flag value accepting the
+// registered features
+func FeaturesFlag(f *flags.AdvancedFeatures) Value {
+	return checkedValue{Value: f, parse: func(value string) error {
+		_, err := ParseFeature(value)
+		return err
+	}}
+}
+
+// GitFlag returns g as a flag value accepting the registered git options
+func GitFlag(g *flags.Git) Value {
+	return checkedValue{Value: g, parse: func(value string) error {
+		_, err := ParseGit(value)
+		return err
+	}}
+}
@@ -0,0 +1,313 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mahibulhaque/gofast/internal/archive"
+	"github.com/mahibulhaque/gofast/internal/executor"
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/modules"
+	"github.com/mahibulhaque/gofast/internal/registry"
+	"github.com/mahibulhaque/gofast/internal/vars"
+	"github.com/mahibulhaque/gofast/pkg/gofast"
+)
+
+//go:embed files/index.html.tmpl
+var indexTemplate string
+
+// maxRequestSize bounds the size of a generate request body
+const maxRequestSize = 1 << 20
+
+// Config configures the generation service
+type Config struct {
+	// MaxConcurrent is the number of projects generated at the same time,
+	// further requests wait for a free slot
+	MaxConcurrent int
+	// Timeout bounds the generation of a single project
+	Timeout time.Duration
+	// Runner runs the external commands of every generation
+	Runner executor.CommandRunner
+}
+
+// GenerateRequest holds the options of a project, mirroring the flags of
+// "gofast create"
+type GenerateRequest struct {
+	Name      string            `json:"name"`
+	Framework string            `json:"framework"`
+	Driver    string            `json:"driver"`
+	Features  []string          `json:"features"`
+	Git       string            `json:"git"`
+	Format    string            `json:"format"`
+	Vars      map[string]string `json:"vars"`
+}
+
+type server struct {
+	cfg   Config
+	slots chan struct{}
+	index *template.Template
+}
+
+// NewHandler returns the HTTP handler of the generation service
+func NewHandler(cfg Config) http.Handler {
+	if cfg.MaxConcurrent < 1 {
+		cfg.MaxConcurrent = 1
+	}
+	if cfg.Runner == nil {
+		cfg.Runner = &executor.Runner{Timeout: executor.DefaultTimeout}
+	}
+
+	s := &server{
+		cfg:   cfg,
+		slots: make(chan struct{}, cfg.MaxConcurrent),
+		index: template.Must(template.New("index").Parse(indexTemplate)),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /api/options", s.handleOptions)
+	mux.HandleFunc("POST /api/generate", s.handleGenerate)
+	return mux
+}
+
+// allowedOptions lists the values accepted for every option
+func allowedOptions() map[string][]string {
+	return map[string][]string{
+		"frameworks": registry.FrameworkValues(),
+		"drivers":    registry.DriverValues(),
+		"features":   registry.FeatureValues(),
+		"git":        {flags.Skip},
+		"formats":    archive.AllowedFormats,
+		"vars":       vars.Keys(),
+	}
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Frameworks, DBDrivers, Features, Formats []string
+		Vars                                     []vars.Definition
+	}{
+		Frameworks: registry.FrameworkValues(),
+		DBDrivers:  registry.DriverValues(),
+		Features:   registry.FeatureValues(),
+		Formats:    archive.AllowedFormats,
+		Vars:       vars.Definitions,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.index.Execute(w, data); err != nil {
+		log.Printf("could not render index page: %v", err)
+	}
+}
+
+func (s *server) handleOptions(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, allowedOptions())
+}
+
+func (s *server) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	req, err := decodeRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	opts, format, err := req.validate()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-r.Context().Done():
+		writeError(w, http.StatusServiceUnavailable, errors.New("timed out waiting for a free generation slot"))
+		return
+	}
+
+	ctx := r.Context()
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	body, err := s.generate(ctx, opts, format)
+	var resolveErr *gofast.ResolveError
+	if errors.As(err, &resolveErr) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		log.Printf("could not generate %s: %v", opts.Name, err)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.Printf("generated %s (%s) in %s", opts.Name, format, time.Since(start).Round(time.Millisecond))
+
+	fileName := modules.GetRootDir(opts.Name) + "." + format.Extension()
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.Header().Set("Content-Length", fmt.Sprint(body.Len()))
+	_, _ = body.WriteTo(w)
+}
+
+// generate creates the project in a temporary directory, that is removed
+// afterwards, and returns it as an archive
+func (s *server) generate(ctx context.Context, opts gofast.Options, format archive.Format) (*bytes.Buffer, error) {
+	dir, err := os.MkdirTemp("", "gofast-serve-*")
+	if err != nil {
+		return nil, fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Printf("could not remove temporary directory %s: %v", dir, err)
+		}
+	}()
+
+	opts.Dir = dir
+	opts.Runner = s.cfg.Runner
+	if _, err := gofast.Generate(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	// The archive is built completely before responding, so that a failure
+	// can still be reported with a proper status code
+	var body bytes.Buffer
+	if err := archive.Write(&body, format, os.DirFS(dir), modules.GetRootDir(opts.Name)); err != nil {
+		return nil, fmt.Errorf("could not create archive: %w", err)
+	}
+	return &body, nil
+}
+
+// decodeRequest reads a JSON body or the fields of the HTML form
+func decodeRequest(r *http.Request) (GenerateRequest, error) {
+	var req GenerateRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			return req, fmt.Errorf("invalid JSON body: %w", err)
+		}
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return req, fmt.Errorf("invalid form: %w", err)
+		}
+		req = GenerateRequest{
+			Name:      r.PostForm.Get("name"),
+			Framework: r.PostForm.Get("framework"),
+			Driver:    r.PostForm.Get("driver"),
+			Features:  r.PostForm["features"],
+			Git:       r.PostForm.Get("git"),
+			Format:    r.PostForm.Get("format"),
+			Vars:      formVars(r.PostForm),
+		}
+	default:
+		return req, fmt.Errorf("unsupported content type '%s'", mediaType)
+	}
+
+	return req, nil
+}
+
+// formVars returns the template variables of the "vars.<key>" fields of the
+// form, an empty field keeps the default
+func formVars(form url.Values) map[string]string {
+	values := make(map[string]string)
+	for field := range form {
+		key, ok := strings.CutPrefix(field, "vars.")
+		if ok && form.Get(field) != "" {
+			values[key] = form.Get(field)
+		}
+	}
+	return values
+}
+
+// validate checks every option with the same rules as the create flags
+func (req GenerateRequest) validate() (gofast.Options, archive.Format, error) {
+	var opts gofast.Options
+
+	if !modules.ValidateModuleName(req.Name) {
+		return opts, "", fmt.Errorf("'%s' is not a valid module name", req.Name)
+	}
+	opts.Name = req.Name
+
+	framework, err := registry.ParseFramework(req.Framework)
+	if err != nil {
+		return opts, "", err
+	}
+	opts.Framework = framework
+
+	driver, err := registry.ParseDriver(orDefault(req.Driver, flags.None.String()))
+	if err != nil {
+		return opts, "", err
+	}
+	opts.DBDriver = driver
+
+	for _, value := range req.Features {
+		feature, err := registry.ParseFeature(value)
+		if err != nil {
+			return opts, "", err
+		}
+		opts.Features = append(opts.Features, gofast.Feature(feature))
+	}
+
+	// A repository would be archived with the identity of the service, the
+	// project is downloaded without one
+	git, err := registry.ParseGit(orDefault(req.Git, flags.Skip))
+	if err != nil {
+		return opts, "", err
+	}
+	if git != flags.Skip {
+		return opts, "", fmt.Errorf("git %s is not available, projects are downloaded without a git repository", git)
+	}
+	opts.Git = git
+
+	opts.Vars = req.Vars
+	if err := opts.Validate(); err != nil {
+		return opts, "", err
+	}
+
+	format, err := archive.ParseFormat(orDefault(req.Format, string(archive.Zip)))
+	if err != nil {
+		return opts, "", err
+	}
+
+	return opts, format, nil
+}
+
+func orDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("could not write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,284 @@
+package serve
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRunner records the directory of every command instead of running it.
+// When block is set, every command waits for it to be closed
+type fakeRunner struct {
+	mu      sync.Mutex
+	dirs    []string
+	started chan struct{}
+	block   chan struct{}
+}
+
+func (r *fakeRunner) Run(ctx context.Context, name string, args []string, dir string) error {
+	r.mu.Lock()
+	r.dirs = append(r.dirs, dir)
+	r.mu.Unlock()
+
+	if r.started != nil {
+		select {
+		case r.started <- struct{}{}:
+		default:
+		}
+	}
+	if r.block != nil {
+		select {
+		case <-r.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// tempDir makes the generations use a new temporary directory, and returns it
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	return dir
+}
+
+func jsonRequest(t *testing.T, req GenerateRequest) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/api/generate", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func formRequest(form url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/generate", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+// zipFiles returns the names of the entries of the zip archive in body
+func zipFiles(t *testing.T, body []byte) []string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  func(t *testing.T) *http.Request
+		fileName string
+		files    []string
+	}{
+		{
+			name: "json",
+			request: func(t *testing.T) *http.Request {
+				return jsonRequest(t, GenerateRequest{Name: "github.com/acme/api", Framework: "chi", Features: []string{"docker"}})
+			},
+			fileName: "api.zip",
+			files:    []string{"api/", "api/cmd/api/main.go", "api/Dockerfile"},
+		},
+		{
+			name: "form",
+			request: func(t *testing.T) *http.Request {
+				return formRequest(url.Values{
+					"name":      {"shop"},
+					"framework": {"gin"},
+					"driver":    {"postgres"},
+					"git":       {"skip"},
+				})
+			},
+			fileName: "shop.zip",
+			files:    []string{"shop/", "shop/cmd/api/main.go", "shop/internal/db/database.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := tempDir(t)
+			runner := &fakeRunner{}
+			handler := NewHandler(Config{Runner: runner})
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.request(t))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/zip" {
+				t.Errorf("content type = %q, want application/zip", got)
+			}
+			if got := w.Header().Get("Content-Disposition"); !strings.Contains(got, tt.fileName) {
+				t.Errorf("content disposition = %q, want filename %s", got, tt.fileName)
+			}
+
+			files := zipFiles(t, w.Body.Bytes())
+			for _, file := range tt.files {
+				if !slices.Contains(files, file) {
+					t.Errorf("archive lacks %s: %v", file, files)
+				}
+			}
+
+			// The project was generated in, and the commands run below, a
+			// temporary directory that is gone once the response is written
+			if len(runner.dirs) == 0 {
+				t.Fatal("no command was run")
+			}
+			for _, dir := range runner.dirs {
+				if !strings.HasPrefix(dir, tmp) {
+					t.Errorf("command ran in %s, outside of %s", dir, tmp)
+				}
+			}
+			entries, err := os.ReadDir(tmp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) > 0 {
+				t.Errorf("temporary directory %s was not removed", entries[0].Name())
+			}
+		})
+	}
+}
+
+func TestGenerateVars(t *testing.T) {
+	tempDir(t)
+	handler := NewHandler(Config{Runner: &fakeRunner{}})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, formRequest(url.Values{"name": {"api"}, "framework": {"chi"}, "vars.port": {"3000"}}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := zr.Open("api/.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer env.Close()
+	content, err := io.ReadAll(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "PORT=3000") {
+		t.Errorf(".env lacks PORT=3000:\n%s", content)
+	}
+}
+
+func TestGenerateBadRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request GenerateRequest
+		err     string
+	}{
+		{
+			name:    "unknown framework",
+			request: GenerateRequest{Name: "api", Framework: "rails"},
+			err:     "Framework to use",
+		},
+		{
+			name:    "git commit",
+			request: GenerateRequest{Name: "api", Framework: "chi", Git: "commit"},
+			err:     "git commit is not available",
+		},
+		{
+			name:    "git stage",
+			request: GenerateRequest{Name: "api", Framework: "chi", Git: "stage"},
+			err:     "git stage is not available",
+		},
+		{
+			name:    "unavailable feature",
+			request: GenerateRequest{Name: "api", Framework: "cli", Features: []string{"docker"}},
+			err:     "Docker",
+		},
+		{
+			name:    "unknown var",
+			request: GenerateRequest{Name: "api", Framework: "chi", Vars: map[string]string{"colour": "blue"}},
+			err:     "colour",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir(t)
+			handler := NewHandler(Config{Runner: &fakeRunner{}})
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, jsonRequest(t, tt.request))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(body["error"], tt.err) {
+				t.Errorf("error = %q, want it to contain %q", body["error"], tt.err)
+			}
+		})
+	}
+}
+
+func TestGenerateConcurrencyLimit(t *testing.T) {
+	tempDir(t)
+	runner := &fakeRunner{started: make(chan struct{}, 1), block: make(chan struct{})}
+	handler := NewHandler(Config{MaxConcurrent: 1, Runner: runner})
+
+	first := httptest.NewRecorder()
+	firstRequest := jsonRequest(t, GenerateRequest{Name: "first", Framework: "chi"})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(first, firstRequest)
+	}()
+	<-runner.started
+
+	// The only slot is taken, so the second request gives up once its
+	// context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, jsonRequest(t, GenerateRequest{Name: "second", Framework: "chi"}).WithContext(ctx))
+	if second.Code != http.StatusServiceUnavailable {
+		t.Errorf("second status = %d, want 503: %s", second.Code, second.Body)
+	}
+
+	close(runner.block)
+	<-done
+	if first.Code != http.StatusOK {
+		t.Errorf("first status = %d, want 200: %s", first.Code, first.Body)
+	}
+
+	third := httptest.NewRecorder()
+	handler.ServeHTTP(third, jsonRequest(t, GenerateRequest{Name: "third", Framework: "chi"}))
+	if third.Code != http.StatusOK {
+		t.Errorf("third status = %d, want 200 once the slot is free: %s", third.Code, third.Body)
+	}
+}
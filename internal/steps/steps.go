@@ -1,6 +1,10 @@
 package steps
 
-import "github.com/mahibulhaque/gofast/internal/flags"
+import (
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/profile"
+	"github.com/mahibulhaque/gofast/internal/registry"
+)
 
 type StepSchema struct {
 	StepName string
@@ -13,120 +17,96 @@ type Steps struct {
 	Steps map[string]StepSchema
 }
 
+// Item is an option of a step, Flag holds the value passed to the
+// matching command line flag. An item with a Disabled reason is shown but
+// cannot be chosen
 type Item struct {
 	Flag, Title, Desc string
+	Disabled          string
 }
 
+// InitSteps builds the wizard steps from the registry
 func InitSteps(projectType flags.Framework, databaseType flags.Database) *Steps {
+	frameworks := make([]Item, len(registry.Frameworks))
+	for i, f := range registry.Frameworks {
+		frameworks[i] = Item{Flag: f.Value.String(), Title: f.Title, Desc: f.Description}
+	}
+
+	drivers := make([]Item, len(registry.Drivers))
+	for i, d := range registry.Drivers {
+		drivers[i] = Item{Flag: d.Value.String(), Title: d.Title, Desc: d.Description}
+	}
+
+	features := make([]Item, len(registry.Features))
+	for i, f := range registry.Features {
+		features[i] = Item{Flag: f.Value, Title: f.Title, Desc: f.Description}
+	}
+
+	gitOptions := make([]Item, len(registry.GitOptions))
+	for i, g := range registry.GitOptions {
+		gitOptions[i] = Item{Flag: g.Value.String(), Title: g.Title, Desc: g.Description}
+	}
+
 	steps := &Steps{
 		map[string]StepSchema{
 			"framework": {
 				StepName: "Go Project Framework",
-				Options: []Item{
-					{
-						Title: "Standard-library",
-						Desc:  "The built-in Go standard library HTTP package",
-					},
-					{
-						Title: "Chi",
-						Desc:  "A lightweight, idiomatic and composable router for building Go HTTP services",
-					},
-					{
-						Title: "Gin",
-						Desc:  "Features a martini-like API with performance that is up to 40 times faster thanks to httprouter",
-					},
-					{
-						Title: "Fiber",
-						Desc:  "An Express inspired web framework built on top of Fasthttp",
-					},
-					{
-						Title: "Gorilla/Mux",
-						Desc:  "Package gorilla/mux implements a request router and dispatcher for matching incoming requests to their respective handler",
-					},
-					{
-						Title: "HttpRouter",
-						Desc:  "HttpRouter is a lightweight high performance HTTP request router for Go",
-					},
-					{
-						Title: "Echo",
-						Desc:  "High performance, extensible, minimalist Go web framework",
-					},
-				},
-				Headers: "What framework do you want to use in your Go project?",
-				Field:   projectType.String(),
+				Options:  frameworks,
+				Headers:  "What framework do you want to use in your Go project?",
+				Field:    projectType.String(),
 			},
 			"driver": {
 				StepName: "Go Project Database Driver",
-				Options: []Item{
-					{
-						Title: "Mysql",
-						Desc:  "MySQL-Driver for Go's database/sql package",
-					},
-					{
-						Title: "Postgres",
-						Desc:  "Go postgres driver for Go's database/sql package"},
-					{
-						Title: "Sqlite",
-						Desc:  "sqlite3 driver conforming to the built-in database/sql interface"},
-					{
-						Title: "Mongo",
-						Desc:  "The MongoDB supported driver for Go."},
-					{
-						Title: "Redis",
-						Desc:  "Redis driver for Go."},
-					{
-						Title: "None",
-						Desc:  "Choose this option if you don't wish to install a specific database driver."},
-				},
-				Headers: "What database driver do you want to use in your Go project?",
-				Field:   databaseType.String(),
+				Options:  drivers,
+				Headers:  "What database driver do you want to use in your Go project?",
+				Field:    databaseType.String(),
 			},
 			"advanced": {
 				StepName: "Advanced Features",
 				Headers:  "Which advanced features do you want?",
-				Options: []Item{
-					{
-						Flag:  "React",
-						Title: "React",
-						Desc:  "Use Vite to spin up a React project in TypeScript.",
-					},
-					{
-						Flag:  "GitHubAction",
-						Title: "Go Project Workflow",
-						Desc:  "Workflow templates for testing, cross-compiling and releasing Go projects",
-					},
-					{
-						Flag:  "Websocket",
-						Title: "Websocket endpoint",
-						Desc:  "Add a websocket endpoint",
-					},
-					{
-						Flag:  "Docker",
-						Title: "Docker",
-						Desc:  "Dockerfile and docker-compose generic configuration for go project",
-					},
-				},
+				Options:  features,
 			},
 			"git": {
 				StepName: "Git Repository",
 				Headers:  "Which git option would you like to select for your project?",
-				Options: []Item{
-					{
-						Title: "Commit",
-						Desc:  "Initialize a new git repository and commit all the changes",
-					},
-					{
-						Title: "Stage",
-						Desc:  "Initialize a new git repository but only stage the changes",
-					},
-					{
-						Title: "Skip",
-						Desc:  "Proceed without initializing a git repository",
-					},
-				},
+				Options:  gitOptions,
 			},
 		},
 	}
 
 	return steps
 }
+
+// DisableUnavailable marks the options of step that have a reason in
+// reasons, keyed by flag value
+func (s StepSchema) DisableUnavailable(reasons map[string]string) StepSchema {
+	options := make([]Item, len(s.Options))
+	for i, item := range s.Options {
+		item.Disabled = reasons[item.Flag]
+		options[i] = item
+	}
+	s.Options = options
+	return s
+}
+
+// ProfileStep lets the user start from one of profiles. The first option,
+// with an empty flag, chooses every option one by one
+func ProfileStep(profiles []profile.Profile) StepSchema {
+	options := []Item{{Title: "Custom", Desc: "Choose every option yourself"}}
+	for _, p := range profiles {
+		item := Item{Flag: p.Name, Title: p.Name, Desc: p.Description}
+		// An invalid profile file is shown, with its error, but cannot be
+		// chosen
+		if p.Err != nil {
+			item.Desc = "Invalid profile file"
+			item.Disabled = p.Err.Error()
+		}
+		options = append(options, item)
+	}
+
+	return StepSchema{
+		StepName: "Project Profile",
+		Options:  options,
+		Headers:  "Do you want to start from a profile?",
+	}
+}
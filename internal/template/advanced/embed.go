@@ -0,0 +1,24 @@
+package advanced
+
+import (
+	_ "embed"
+)
+
+//go:embed files/embed/doc.go.tmpl
+var embedDocTemplate []byte
+
+//go:embed files/embed/embed.go.tmpl
+var embedProdTemplate []byte
+
+//go:embed files/embed/dev.go.tmpl
+var embedDevTemplate []byte
+
+// EmbedFiles returns the templates of the package serving the frontend from
+// the API server, by slash separated path relative to the project root
+func EmbedFiles() map[string][]byte {
+	return map[string][]byte{
+		"frontend/doc.go":   embedDocTemplate,
+		"frontend/embed.go": embedProdTemplate,
+		"frontend/dev.go":   embedDevTemplate,
+	}
+}
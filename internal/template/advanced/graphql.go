@@ -0,0 +1,43 @@
+package advanced
+
+import (
+	_ "embed"
+)
+
+//go:embed files/graphql/schema.graphqls.tmpl
+var graphqlSchemaTemplate []byte
+
+//go:embed files/graphql/gqlgen.yml.tmpl
+var gqlgenConfigTemplate []byte
+
+//go:embed files/graphql/generated.go.tmpl
+var graphqlGeneratedTemplate []byte
+
+//go:embed files/graphql/models_gen.go.tmpl
+var graphqlModelsTemplate []byte
+
+//go:embed files/graphql/resolver.go.tmpl
+var graphqlResolverTemplate []byte
+
+//go:embed files/graphql/schema.resolvers.go.tmpl
+var graphqlSchemaResolversTemplate []byte
+
+//go:embed files/graphql/graphql.go.tmpl
+var graphqlHandlerTemplate []byte
+
+// GraphqlFiles returns the templates of the GraphQL feature, by slash
+// separated path relative to the project root. The code gqlgen generates
+// from the schema is included so the project builds before "make generate"
+// is run
+func GraphqlFiles() map[string][]byte {
+	return map[string][]byte{
+		"graph/schema.graphqls":      graphqlSchemaTemplate,
+		"graph/generated.go":         graphqlGeneratedTemplate,
+		"graph/model/models_gen.go":  graphqlModelsTemplate,
+		"graph/resolver.go":          graphqlResolverTemplate,
+		"graph/schema.resolvers.go":  graphqlSchemaResolversTemplate,
+		"gqlgen.yml":                 gqlgenConfigTemplate,
+		"tools.go":                   toolsTemplate,
+		"internal/server/graphql.go": graphqlHandlerTemplate,
+	}
+}
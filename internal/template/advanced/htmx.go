@@ -0,0 +1,54 @@
+package advanced
+
+import (
+	_ "embed"
+)
+
+//go:embed files/htmx/assets.go.tmpl
+var htmxAssetsTemplate []byte
+
+//go:embed files/htmx/handlers.go.tmpl
+var htmxHandlersTemplate []byte
+
+//go:embed files/htmx/layout.templ.tmpl
+var htmxLayoutTemplate []byte
+
+//go:embed files/htmx/layout_templ.go.tmpl
+var htmxLayoutGeneratedTemplate []byte
+
+//go:embed files/htmx/components.templ.tmpl
+var htmxComponentsTemplate []byte
+
+//go:embed files/htmx/components_templ.go.tmpl
+var htmxComponentsGeneratedTemplate []byte
+
+//go:embed files/htmx/hello.templ.tmpl
+var htmxHelloTemplate []byte
+
+//go:embed files/htmx/hello_templ.go.tmpl
+var htmxHelloGeneratedTemplate []byte
+
+//go:embed files/htmx/input.css.tmpl
+var htmxInputCssTemplate []byte
+
+//go:embed files/htmx/favicon.svg.tmpl
+var htmxFaviconTemplate []byte
+
+// HtmxFiles returns the templates of the HTMX feature, by slash separated
+// path relative to the project root. The code templ generates from the
+// components is included so the project builds before "make templ" is run
+func HtmxFiles() map[string][]byte {
+	return map[string][]byte{
+		"internal/views/assets.go":           htmxAssetsTemplate,
+		"internal/views/handlers.go":         htmxHandlersTemplate,
+		"internal/views/layout.templ":        htmxLayoutTemplate,
+		"internal/views/layout_templ.go":     htmxLayoutGeneratedTemplate,
+		"internal/views/components.templ":    htmxComponentsTemplate,
+		"internal/views/components_templ.go": htmxComponentsGeneratedTemplate,
+		"internal/views/hello.templ":         htmxHelloTemplate,
+		"internal/views/hello_templ.go":      htmxHelloGeneratedTemplate,
+		"internal/views/styles/input.css":    htmxInputCssTemplate,
+		"internal/views/assets/favicon.svg":  htmxFaviconTemplate,
+		"tools.go":                           toolsTemplate,
+	}
+}
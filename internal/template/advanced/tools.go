@@ -0,0 +1,11 @@
+package advanced
+
+import (
+	_ "embed"
+)
+
+// toolsTemplate imports the generators of every selected feature, it is
+// written by each of them
+//
+//go:embed files/tools/tools.go.tmpl
+var toolsTemplate []byte
@@ -0,0 +1,36 @@
+package advanced
+
+import (
+	_ "embed"
+)
+
+//go:embed files/web/main.go.tmpl
+var webMainTemplate []byte
+
+//go:embed files/web/server.go.tmpl
+var webServerTemplate []byte
+
+//go:embed files/web/routes.go.tmpl
+var webRoutesTemplate []byte
+
+//go:embed files/web/templates/layout.html.tmpl
+var webLayoutTemplate []byte
+
+//go:embed files/web/templates/status.html.tmpl
+var webStatusTemplate []byte
+
+// WebMainTemplate is the main package of the web binary
+func WebMainTemplate() []byte {
+	return webMainTemplate
+}
+
+// WebFiles returns the templates of the package of the web binary, by slash
+// separated path relative to the project root
+func WebFiles() map[string][]byte {
+	return map[string][]byte{
+		"internal/web/server.go":             webServerTemplate,
+		"internal/web/routes.go":             webRoutesTemplate,
+		"internal/web/templates/layout.html": webLayoutTemplate,
+		"internal/web/templates/status.html": webStatusTemplate,
+	}
+}
@@ -0,0 +1,25 @@
+package advanced
+
+import (
+	_ "embed"
+)
+
+//go:embed files/worker/main.go.tmpl
+var workerMainTemplate []byte
+
+//go:embed files/worker/worker.go.tmpl
+var workerTemplate []byte
+
+//go:embed files/worker/jobs.go.tmpl
+var workerJobsTemplate []byte
+
+// WorkerFiles returns the templates of the worker feature, by slash
+// separated path relative to the project root. The queue the worker takes
+// its jobs from is provided by the database driver
+func WorkerFiles() map[string][]byte {
+	return map[string][]byte{
+		"cmd/worker/main.go":        workerMainTemplate,
+		"internal/worker/worker.go": workerTemplate,
+		"internal/worker/jobs.go":   workerJobsTemplate,
+	}
+}
@@ -15,6 +15,12 @@ var postgresEnvTemplate []byte
 //go:embed files/tests/postgres.tmpl
 var postgresTestcontainersTemplate []byte
 
+//go:embed files/queue/postgres.tmpl
+var postgresQueueTemplate []byte
+
+//go:embed files/queue_tests/postgres.tmpl
+var postgresQueueTestsTemplate []byte
+
 func (m PostgresTemplate) Service() []byte {
 	return postgresServiceTemplate
 }
@@ -26,3 +32,12 @@ func (m PostgresTemplate) Env() []byte {
 func (m PostgresTemplate) Tests() []byte {
 	return postgresTestcontainersTemplate
 }
+
+// Queue is the job queue of the worker feature, kept in Postgres
+func (m PostgresTemplate) Queue() []byte {
+	return postgresQueueTemplate
+}
+
+func (m PostgresTemplate) QueueTests() []byte {
+	return postgresQueueTestsTemplate
+}
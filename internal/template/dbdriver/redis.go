@@ -15,6 +15,12 @@ var redisEnvTemplate []byte
 //go:embed files/tests/redis.tmpl
 var redisTestcontainersTemplate []byte
 
+//go:embed files/queue/redis.tmpl
+var redisQueueTemplate []byte
+
+//go:embed files/queue_tests/redis.tmpl
+var redisQueueTestsTemplate []byte
+
 func (r RedisTemplate) Service() []byte {
 	return redisServiceTemplate
 }
@@ -26,3 +32,12 @@ func (r RedisTemplate) Env() []byte {
 func (r RedisTemplate) Tests() []byte {
 	return redisTestcontainersTemplate
 }
+
+// Queue is the job queue of the worker feature, kept in Redis
+func (r RedisTemplate) Queue() []byte {
+	return redisQueueTemplate
+}
+
+func (r RedisTemplate) QueueTests() []byte {
+	return redisQueueTestsTemplate
+}
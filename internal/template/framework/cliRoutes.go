@@ -0,0 +1,64 @@
+package framework
+
+import (
+	_ "embed"
+)
+
+//go:embed files/main/cli_main.go.tmpl
+var cliMainTemplate []byte
+
+//go:embed files/cli/root.go.tmpl
+var cliRootTemplate []byte
+
+//go:embed files/cli/greet.go.tmpl
+var cliGreetTemplate []byte
+
+//go:embed files/cli/version.go.tmpl
+var cliVersionTemplate []byte
+
+//go:embed files/cli/db.go.tmpl
+var cliDBTemplate []byte
+
+// CliTemplates contains the methods used for building a command line
+// application with [github.com/spf13/cobra]. Its commands are in
+// internal/cmd, main only runs them
+type CliTemplates struct{}
+
+func (c CliTemplates) Main() []byte {
+	return cliMainTemplate
+}
+
+// Server is nil, a CLI serves nothing
+func (c CliTemplates) Server() []byte {
+	return nil
+}
+
+// Routes is nil, a CLI has commands instead of routes
+func (c CliTemplates) Routes() []byte {
+	return nil
+}
+
+// WebsocketImports is nil, a CLI has no websocket endpoint
+func (c CliTemplates) WebsocketImports() []byte {
+	return nil
+}
+
+// RequestPackage is nil, a CLI reads arguments instead of requests
+func (c CliTemplates) RequestPackage() []byte {
+	return nil
+}
+
+// ResponsePackage is nil, a CLI writes to the terminal instead of responses
+func (c CliTemplates) ResponsePackage() []byte {
+	return nil
+}
+
+// Files returns the commands, the db command is left out without a database
+func (c CliTemplates) Files() map[string][]byte {
+	return map[string][]byte{
+		"internal/cmd/root.go":    cliRootTemplate,
+		"internal/cmd/greet.go":   cliGreetTemplate,
+		"internal/cmd/version.go": cliVersionTemplate,
+		"internal/cmd/db.go":      cliDBTemplate,
+	}
+}
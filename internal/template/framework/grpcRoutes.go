@@ -0,0 +1,55 @@
+package framework
+
+import (
+	_ "embed"
+	"maps"
+
+	"github.com/mahibulhaque/gofast/internal/template/protobuf"
+)
+
+//go:embed files/main/grpc_main.go.tmpl
+var grpcMainTemplate []byte
+
+//go:embed files/server/grpc_server.go.tmpl
+var grpcServerTemplate []byte
+
+//go:embed files/routes/grpc.go.tmpl
+var grpcRoutesTemplate []byte
+
+// GrpcTemplates contains the methods used for building a gRPC service with
+// [google.golang.org/grpc]. The code generated from the sample proto file
+// is included so the project builds before "make proto" is run
+type GrpcTemplates struct{}
+
+func (g GrpcTemplates) Main() []byte {
+	return grpcMainTemplate
+}
+
+func (g GrpcTemplates) Server() []byte {
+	return grpcServerTemplate
+}
+
+func (g GrpcTemplates) Routes() []byte {
+	return grpcRoutesTemplate
+}
+
+// WebsocketImports is nil, a gRPC service has no websocket endpoint
+func (g GrpcTemplates) WebsocketImports() []byte {
+	return nil
+}
+
+// RequestPackage is nil, requests are decoded by the generated code
+func (g GrpcTemplates) RequestPackage() []byte {
+	return nil
+}
+
+// ResponsePackage is nil, responses are encoded by the generated code
+func (g GrpcTemplates) ResponsePackage() []byte {
+	return nil
+}
+
+func (g GrpcTemplates) Files() map[string][]byte {
+	files := protobuf.Files()
+	maps.Copy(files, protobuf.GrpcFiles())
+	return files
+}
@@ -0,0 +1,90 @@
+// Package frontend holds the templates of the Vite frontends generated in
+// the frontend directory of a project. Every file is embedded and every
+// package version pinned, so the same frontend is generated offline
+package frontend
+
+import (
+	_ "embed"
+	"maps"
+)
+
+type ReactTemplate struct{}
+
+//go:embed files/react/package.json.tmpl
+var reactPackageJsonTemplate []byte
+
+//go:embed files/react/index.html.tmpl
+var reactIndexHtmlFile []byte
+
+//go:embed files/react/eslint.config.js.tmpl
+var reactEslintConfigFile []byte
+
+//go:embed files/react/prettier.config.js.tmpl
+var reactPrettierConfigFile []byte
+
+//go:embed files/react/tsconfig.json.tmpl
+var reactTsConfigJsonFile []byte
+
+//go:embed files/react/tsconfig.app.json.tmpl
+var reactTsConfigAppJsonFile []byte
+
+//go:embed files/react/vite.config.ts.tmpl
+var reactViteConfigFile []byte
+
+//go:embed files/react/components.json.tmpl
+var reactComponentsJsonFile []byte
+
+//go:embed files/react/src/main.tsx.tmpl
+var reactMainFile []byte
+
+//go:embed files/react/src/styles.css.tmpl
+var reactStylesCssFile []byte
+
+//go:embed files/react/src/routes/root.tsx.tmpl
+var reactRootRouteFile []byte
+
+//go:embed files/react/src/routes/index.tsx.tmpl
+var reactIndexRouteFile []byte
+
+//go:embed files/react/src/routes/demo.tanstack-query.tsx.tmpl
+var reactDemoTanstackQueryRouteFile []byte
+
+//go:embed files/react/src/components/Header.tsx.tmpl
+var reactHeaderComponentFile []byte
+
+//go:embed files/react/src/lib/utils.ts.tmpl
+var reactUtilsFile []byte
+
+//go:embed files/react/src/assets/react.svg.tmpl
+var reactLogoFile []byte
+
+// Templates sets the port of the Vite dev server in the scripts
+func (r ReactTemplate) Templates() map[string][]byte {
+	return map[string][]byte{
+		"package.json": reactPackageJsonTemplate,
+	}
+}
+
+// Files are a TanStack Router and Query app styled with Tailwind and set up
+// for shadcn/ui
+func (r ReactTemplate) Files() map[string][]byte {
+	files := sharedFiles()
+	maps.Copy(files, map[string][]byte{
+		"index.html":                         reactIndexHtmlFile,
+		"eslint.config.js":                   reactEslintConfigFile,
+		"prettier.config.js":                 reactPrettierConfigFile,
+		"tsconfig.json":                      reactTsConfigJsonFile,
+		"tsconfig.app.json":                  reactTsConfigAppJsonFile,
+		"vite.config.ts":                     reactViteConfigFile,
+		"components.json":                    reactComponentsJsonFile,
+		"src/main.tsx":                       reactMainFile,
+		"src/styles.css":                     reactStylesCssFile,
+		"src/routes/__root.tsx":              reactRootRouteFile,
+		"src/routes/index.tsx":               reactIndexRouteFile,
+		"src/routes/demo.tanstack-query.tsx": reactDemoTanstackQueryRouteFile,
+		"src/components/Header.tsx":          reactHeaderComponentFile,
+		"src/lib/utils.ts":                   reactUtilsFile,
+		"src/assets/react.svg":               reactLogoFile,
+	})
+	return files
+}
@@ -0,0 +1,38 @@
+package frontend
+
+import (
+	_ "embed"
+)
+
+//go:embed files/shared/gitignore.tmpl
+var gitignoreFile []byte
+
+//go:embed files/shared/tsconfig.json.tmpl
+var tsConfigJsonFile []byte
+
+//go:embed files/shared/tsconfig.node.json.tmpl
+var tsConfigNodeJsonFile []byte
+
+//go:embed files/shared/src/vite-env.d.ts.tmpl
+var viteEnvFile []byte
+
+//go:embed files/shared/src/style.css.tmpl
+var styleCssFile []byte
+
+// sharedFiles are written for every frontend, a frontend may replace them
+func sharedFiles() map[string][]byte {
+	return map[string][]byte{
+		".gitignore":         gitignoreFile,
+		"tsconfig.node.json": tsConfigNodeJsonFile,
+		"src/vite-env.d.ts":  viteEnvFile,
+	}
+}
+
+// sampleFiles are shared by the frontends showing the message of the API
+// on a single page
+func sampleFiles() map[string][]byte {
+	files := sharedFiles()
+	files["tsconfig.json"] = tsConfigJsonFile
+	files["src/style.css"] = styleCssFile
+	return files
+}
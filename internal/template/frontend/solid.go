@@ -0,0 +1,46 @@
+package frontend
+
+import (
+	_ "embed"
+	"maps"
+)
+
+type SolidTemplate struct{}
+
+//go:embed files/solid/index.html.tmpl
+var solidIndexHtmlFile []byte
+
+//go:embed files/solid/package.json.tmpl
+var solidPackageJsonFile []byte
+
+//go:embed files/solid/tsconfig.app.json.tmpl
+var solidTsConfigAppJsonFile []byte
+
+//go:embed files/solid/src/index.tsx.tmpl
+var solidMainFile []byte
+
+//go:embed files/solid/vite.config.ts.tmpl
+var solidViteConfigTemplate []byte
+
+//go:embed files/solid/src/App.tsx.tmpl
+var solidAppTemplate []byte
+
+// Templates set the port of the Vite dev server and show the message of
+// the API
+func (s SolidTemplate) Templates() map[string][]byte {
+	return map[string][]byte{
+		"vite.config.ts": solidViteConfigTemplate,
+		"src/App.tsx":    solidAppTemplate,
+	}
+}
+
+func (s SolidTemplate) Files() map[string][]byte {
+	files := sampleFiles()
+	maps.Copy(files, map[string][]byte{
+		"index.html":        solidIndexHtmlFile,
+		"package.json":      solidPackageJsonFile,
+		"tsconfig.app.json": solidTsConfigAppJsonFile,
+		"src/index.tsx":     solidMainFile,
+	})
+	return files
+}
@@ -0,0 +1,54 @@
+package frontend
+
+import (
+	_ "embed"
+	"maps"
+)
+
+type SvelteTemplate struct{}
+
+//go:embed files/svelte/index.html.tmpl
+var svelteIndexHtmlFile []byte
+
+//go:embed files/svelte/package.json.tmpl
+var sveltePackageJsonFile []byte
+
+//go:embed files/svelte/tsconfig.app.json.tmpl
+var svelteTsConfigAppJsonFile []byte
+
+//go:embed files/svelte/svelte.config.js.tmpl
+var svelteConfigFile []byte
+
+//go:embed files/svelte/src/vite-env.d.ts.tmpl
+var svelteViteEnvFile []byte
+
+//go:embed files/svelte/src/main.ts.tmpl
+var svelteMainFile []byte
+
+//go:embed files/svelte/vite.config.ts.tmpl
+var svelteViteConfigTemplate []byte
+
+//go:embed files/svelte/src/App.svelte.tmpl
+var svelteAppTemplate []byte
+
+// Templates set the port of the Vite dev server and show the message of
+// the API
+func (s SvelteTemplate) Templates() map[string][]byte {
+	return map[string][]byte{
+		"vite.config.ts": svelteViteConfigTemplate,
+		"src/App.svelte": svelteAppTemplate,
+	}
+}
+
+func (s SvelteTemplate) Files() map[string][]byte {
+	files := sampleFiles()
+	maps.Copy(files, map[string][]byte{
+		"index.html":        svelteIndexHtmlFile,
+		"package.json":      sveltePackageJsonFile,
+		"tsconfig.app.json": svelteTsConfigAppJsonFile,
+		"svelte.config.js":  svelteConfigFile,
+		"src/vite-env.d.ts": svelteViteEnvFile,
+		"src/main.ts":       svelteMainFile,
+	})
+	return files
+}
@@ -0,0 +1,46 @@
+package frontend
+
+import (
+	_ "embed"
+	"maps"
+)
+
+type VueTemplate struct{}
+
+//go:embed files/vue/index.html.tmpl
+var vueIndexHtmlFile []byte
+
+//go:embed files/vue/package.json.tmpl
+var vuePackageJsonFile []byte
+
+//go:embed files/vue/tsconfig.app.json.tmpl
+var vueTsConfigAppJsonFile []byte
+
+//go:embed files/vue/src/main.ts.tmpl
+var vueMainFile []byte
+
+//go:embed files/vue/vite.config.ts.tmpl
+var vueViteConfigTemplate []byte
+
+//go:embed files/vue/src/App.vue.tmpl
+var vueAppTemplate []byte
+
+// Templates set the port of the Vite dev server and show the message of
+// the API
+func (v VueTemplate) Templates() map[string][]byte {
+	return map[string][]byte{
+		"vite.config.ts": vueViteConfigTemplate,
+		"src/App.vue":    vueAppTemplate,
+	}
+}
+
+func (v VueTemplate) Files() map[string][]byte {
+	files := sampleFiles()
+	maps.Copy(files, map[string][]byte{
+		"index.html":        vueIndexHtmlFile,
+		"package.json":      vuePackageJsonFile,
+		"tsconfig.app.json": vueTsConfigAppJsonFile,
+		"src/main.ts":       vueMainFile,
+	})
+	return files
+}
@@ -0,0 +1,52 @@
+// Package protobuf holds the sample protobuf service shared by the gRPC
+// framework and the Connect feature, along with the Go code generated from
+// it. The generated code is included so a new project builds before
+// "make proto" is run.
+package protobuf
+
+import (
+	_ "embed"
+)
+
+//go:embed files/greeter.proto.tmpl
+var protoTemplate []byte
+
+//go:embed files/greeter.pb.go.tmpl
+var protoGoTemplate []byte
+
+//go:embed files/greeter_grpc.pb.go.tmpl
+var grpcGoTemplate []byte
+
+//go:embed files/greeter.connect.go.tmpl
+var connectGoTemplate []byte
+
+//go:embed files/buf.yaml.tmpl
+var bufTemplate []byte
+
+//go:embed files/buf.gen.yaml.tmpl
+var bufGenTemplate []byte
+
+// Files returns the templates of the proto file, its messages and the buf
+// configuration, by slash separated path relative to the project root
+func Files() map[string][]byte {
+	return map[string][]byte{
+		"proto/greeter/v1/greeter.proto": protoTemplate,
+		"gen/greeter/v1/greeter.pb.go":   protoGoTemplate,
+		"buf.yaml":                       bufTemplate,
+		"buf.gen.yaml":                   bufGenTemplate,
+	}
+}
+
+// GrpcFiles returns the templates of the grpc-go service code
+func GrpcFiles() map[string][]byte {
+	return map[string][]byte{
+		"gen/greeter/v1/greeter_grpc.pb.go": grpcGoTemplate,
+	}
+}
+
+// ConnectFiles returns the templates of the connect-go handler code
+func ConnectFiles() map[string][]byte {
+	return map[string][]byte{
+		"gen/greeter/v1/greeterv1connect/greeter.connect.go": connectGoTemplate,
+	}
+}
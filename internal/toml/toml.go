@@ -0,0 +1,166 @@
+// Package toml reads the subset of TOML the gofast config, profile and
+// theme files are written in.
+package toml
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Value is the value of a key in a TOML file, a string or an array of
+// strings
+type Value struct {
+	String  string
+	Array   []string
+	IsArray bool
+}
+
+// Decode reads the subset of TOML the gofast files are written in:
+// "key = value" lines holding a string or an array of strings on a single
+// line, and [table] headers, whose keys are passed to set as "table.key".
+// Empty lines and comments starting with # outside of a string are
+// ignored. Errors are prefixed with their line
+func Decode(r io.Reader, set func(key string, value Value) error) error {
+	var table string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			name, rest, ok := strings.Cut(text[1:], "]")
+			if !ok || !isComment(rest) {
+				return fmt.Errorf("line %d: '%s' is not a [table] header", line, text)
+			}
+			table = strings.TrimSpace(name)
+			if table == "" {
+				return fmt.Errorf("line %d: empty table name", line)
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(text, "=")
+		if !ok {
+			return fmt.Errorf("line %d: '%s' is not a key = \"value\" line", line, text)
+		}
+		key, raw = strings.TrimSpace(key), strings.TrimSpace(raw)
+		if key == "" {
+			return fmt.Errorf("line %d: '%s' has no key", line, text)
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+
+		value, err := parseValue(raw)
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %w", line, key, err)
+		}
+
+		if err := set(key, value); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseValue reads a string, an array of strings or a bare value such as
+// true, followed by an optional comment
+func parseValue(raw string) (Value, error) {
+	switch {
+	case strings.HasPrefix(raw, "["):
+		array, err := parseArray(raw)
+		return Value{Array: array, IsArray: true}, err
+	case strings.HasPrefix(raw, `"`), strings.HasPrefix(raw, "'"):
+		value, rest, err := parseString(raw)
+		if err != nil {
+			return Value{}, err
+		}
+		if !isComment(rest) {
+			return Value{}, fmt.Errorf("unexpected '%s' after the string", strings.TrimSpace(rest))
+		}
+		return Value{String: value}, nil
+	}
+
+	value, _, _ := strings.Cut(raw, "#")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Value{}, fmt.Errorf("missing value")
+	}
+	if strings.ContainsAny(value, `"'`) {
+		return Value{}, fmt.Errorf("'%s' is not a quoted string", value)
+	}
+	return Value{String: value}, nil
+}
+
+// parseArray returns the strings of a one line array, e.g. ["a", 'b']
+func parseArray(raw string) ([]string, error) {
+	array := []string{}
+	rest := strings.TrimSpace(raw[1:])
+	for {
+		if next, ok := strings.CutPrefix(rest, "]"); ok {
+			if !isComment(next) {
+				return nil, fmt.Errorf("unexpected '%s' after the array", strings.TrimSpace(next))
+			}
+			return array, nil
+		}
+		if rest == "" || rest[0] == '#' {
+			return nil, fmt.Errorf("'%s' is not an array on a single line", raw)
+		}
+
+		value, next, err := parseString(rest)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not an array of strings: %w", raw, err)
+		}
+		array = append(array, value)
+
+		rest = strings.TrimSpace(next)
+		if next, ok := strings.CutPrefix(rest, ","); ok {
+			rest = strings.TrimSpace(next)
+		} else if !strings.HasPrefix(rest, "]") {
+			return nil, fmt.Errorf("'%s' is not an array of strings", raw)
+		}
+	}
+}
+
+// parseString reads the basic ("...") or literal ('...') string s starts
+// with, and returns its value and the text after it
+func parseString(s string) (value string, rest string, err error) {
+	if s == "" || s[0] != '"' && s[0] != '\'' {
+		return "", "", fmt.Errorf("'%s' is not a quoted string", s)
+	}
+
+	if s[0] == '\'' {
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string %s", s)
+		}
+		return s[1 : end+1], s[end+2:], nil
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("invalid escape in %s", s[:i+1])
+			}
+			return value, s[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string %s", s)
+}
+
+// isComment reports whether rest, the text after a value, is empty or a
+// comment
+func isComment(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
@@ -0,0 +1,150 @@
+package toml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]Value
+		// err is the expected error, empty when the input is valid
+		err string
+	}{
+		{
+			name: "strings",
+			input: `# gofast
+framework = "chi"
+driver = 'postgres'
+git=skip
+`,
+			want: map[string]Value{
+				"framework": {String: "chi"},
+				"driver":    {String: "postgres"},
+				"git":       {String: "skip"},
+			},
+		},
+		{
+			name: "comments after values",
+			input: `framework = "chi" # default
+driver = 'none'# no database
+git = skip # for now
+features = ["docker", 'react'] # both
+[vars] # template variables
+port = "3000"
+`,
+			want: map[string]Value{
+				"framework": {String: "chi"},
+				"driver":    {String: "none"},
+				"git":       {String: "skip"},
+				"features":  {Array: []string{"docker", "react"}, IsArray: true},
+				"vars.port": {String: "3000"},
+			},
+		},
+		{
+			name:  "hash and quotes inside strings",
+			input: `description = "API # v2 with \"quotes\"" # comment`,
+			want: map[string]Value{
+				"description": {String: `API # v2 with "quotes"`},
+			},
+		},
+		{
+			name:  "two strings",
+			input: "description = 'api'\nauthor = 'it''s'\n",
+			err:   `line 2: author: unexpected ''s'' after the string`,
+		},
+		{
+			name:  "escapes",
+			input: `description = "tab\tand # not a comment"` + "\n" + `path = 'C:\dir # literal'`,
+			want: map[string]Value{
+				"description": {String: "tab\tand # not a comment"},
+				"path":        {String: `C:\dir # literal`},
+			},
+		},
+		{
+			name:  "arrays",
+			input: "empty = []\none = [ \"a\" ]\ntrailing = [\"a\", \"b\",]\n",
+			want: map[string]Value{
+				"empty":    {Array: []string{}, IsArray: true},
+				"one":      {Array: []string{"a"}, IsArray: true},
+				"trailing": {Array: []string{"a", "b"}, IsArray: true},
+			},
+		},
+		{
+			name:  "missing closing quote",
+			input: "\nframework = \"chi\n",
+			err:   `line 2: framework: unterminated string "chi`,
+		},
+		{
+			name:  "missing closing literal quote",
+			input: "framework = 'chi # default\n",
+			err:   `line 1: framework: unterminated string 'chi # default`,
+		},
+		{
+			name:  "text after the string",
+			input: `framework = "chi" "gin"`,
+			err:   `line 1: framework: unexpected '"gin"' after the string`,
+		},
+		{
+			name:  "quote in a bare value",
+			input: `framework = chi"`,
+			err:   `line 1: framework: 'chi"' is not a quoted string`,
+		},
+		{
+			name:  "missing value",
+			input: "framework = # later",
+			err:   "line 1: framework: missing value",
+		},
+		{
+			name:  "unterminated array",
+			input: "features = [\"docker\"\n",
+			err:   `line 1: features: '["docker"' is not an array of strings`,
+		},
+		{
+			name:  "array on several lines",
+			input: "features = [\n  \"docker\",\n]\n",
+			err:   "line 1: features: '[' is not an array on a single line",
+		},
+		{
+			name:  "not an assignment",
+			input: "framework\n",
+			err:   `line 1: 'framework' is not a key = "value" line`,
+		},
+		{
+			name:  "empty table",
+			input: "[ ]\n",
+			err:   "line 1: empty table name",
+		},
+		{
+			name:  "unclosed table",
+			input: "[vars\n",
+			err:   "line 1: '[vars' is not a [table] header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]Value)
+			err := Decode(strings.NewReader(tt.input), func(key string, value Value) error {
+				got[key] = value
+				return nil
+			})
+
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("err = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Package accessible asks the wizard steps as plain numbered line prompts,
+// for screen readers, dumb terminals and scripts. Nothing is colored,
+// animated or redrawn, every answer is a line read from the input.
+package accessible
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mahibulhaque/gofast/internal/steps"
+	"github.com/mahibulhaque/gofast/internal/tui/components/form"
+	"github.com/mahibulhaque/gofast/internal/tui/components/list"
+	"github.com/mahibulhaque/gofast/internal/tui/components/textinput"
+)
+
+// ErrInputEnded is returned when the input ends before a question is
+// answered
+var ErrInputEnded = errors.New("the input ended before every question was answered")
+
+// Prompter asks questions on out and reads the answers from in
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New returns a Prompter reading answers from in and writing questions to out
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// readLine returns the next line of the input without surrounding spaces
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", ErrInputEnded
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Input asks header until validate accepts the answer, which is stored in
+// output. An empty answer is never accepted
+func (p *Prompter) Input(header string, output *textinput.Output, validate func(string) error) error {
+	fmt.Fprintln(p.out, header)
+	for {
+		fmt.Fprint(p.out, "> ")
+		answer, err := p.readLine()
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			fmt.Fprintln(p.out, "Please enter a value.")
+			continue
+		}
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				fmt.Fprintf(p.out, "Error: %v\n", err)
+				continue
+			}
+		}
+		output.Output = answer
+		return nil
+	}
+}
+
+// Select asks for one option of step, by number, and stores it in selection
+func (p *Prompter) Select(step steps.StepSchema, selection *list.Selection) error {
+	p.printOptions(step)
+	for {
+		fmt.Fprintf(p.out, "Enter a number from 1 to %d: ", len(step.Options))
+		answer, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		index, err := p.parseChoice(step, answer)
+		if err != nil {
+			fmt.Fprintf(p.out, "Error: %v\n", err)
+			continue
+		}
+
+		item := step.Options[index]
+		selection.Choice = item.Title
+		selection.Flag = item.Flag
+		selection.IsSelected = true
+		return nil
+	}
+}
+
+// MultiSelect asks for any number of options of step, as comma separated
+// numbers, until validate accepts them. The chosen options are stored in
+// selection in list order
+func (p *Prompter) MultiSelect(step steps.StepSchema, selection *list.MultiSelection, validate func(flags []string) error) error {
+	p.printOptions(step)
+	for {
+		fmt.Fprint(p.out, "Enter numbers separated by commas, or nothing for none: ")
+		answer, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		chosen := make(map[int]bool)
+		var parseErr error
+		for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+			index, err := p.parseChoice(step, field)
+			if err != nil {
+				parseErr = err
+				break
+			}
+			chosen[index] = true
+		}
+		if parseErr != nil {
+			fmt.Fprintf(p.out, "Error: %v\n", parseErr)
+			continue
+		}
+
+		var choices, flags []string
+		for i, item := range step.Options {
+			if chosen[i] {
+				choices = append(choices, item.Title)
+				flags = append(flags, item.Flag)
+			}
+		}
+		if validate != nil {
+			if err := validate(flags); err != nil {
+				fmt.Fprintf(p.out, "Error: %v\n", err)
+				continue
+			}
+		}
+
+		if selection.Selected == nil {
+			selection.Selected = make(map[int]bool)
+		}
+		for index := range chosen {
+			selection.Selected[index] = true
+		}
+		selection.Choices = choices
+		selection.Flags = flags
+		selection.Confirmed = true
+		return nil
+	}
+}
+
+// Form asks for every field, an empty answer keeps the current value. The
+// answers are stored in output by field key
+func (p *Prompter) Form(header string, fields []form.Field, output *form.Output) error {
+	fmt.Fprintln(p.out, header)
+	output.Values = make(map[string]string, len(fields))
+
+	for _, field := range fields {
+		for {
+			fmt.Fprintf(p.out, "%s (%s) [%s]: ", field.Label, field.Description, field.Value)
+			answer, err := p.readLine()
+			if err != nil {
+				return err
+			}
+			if answer == "" {
+				answer = field.Value
+			}
+			if field.Validate != nil {
+				if err := field.Validate(answer); err != nil {
+					fmt.Fprintf(p.out, "Error: %v\n", err)
+					continue
+				}
+			}
+			output.Values[field.Key] = answer
+			break
+		}
+	}
+	return nil
+}
+
+// Message writes a line of information
+func (p *Prompter) Message(message string) {
+	fmt.Fprintln(p.out, message)
+}
+
+func (p *Prompter) printOptions(step steps.StepSchema) {
+	fmt.Fprintln(p.out, step.Headers)
+	for i, item := range step.Options {
+		line := fmt.Sprintf("  %d. %s", i+1, item.Title)
+		if item.Desc != "" {
+			line += ": " + item.Desc
+		}
+		if item.Disabled != "" {
+			line += " (unavailable: " + item.Disabled + ")"
+		}
+		fmt.Fprintln(p.out, line)
+	}
+}
+
+// parseChoice returns the index of the option numbered answer
+func (p *Prompter) parseChoice(step steps.StepSchema, answer string) (int, error) {
+	number, err := strconv.Atoi(answer)
+	if err != nil || number < 1 || number > len(step.Options) {
+		return 0, fmt.Errorf("'%s' is not a number from 1 to %d", answer, len(step.Options))
+	}
+	if reason := step.Options[number-1].Disabled; reason != "" {
+		return 0, fmt.Errorf("%s is unavailable: %s", step.Options[number-1].Title, reason)
+	}
+	return number - 1, nil
+}
@@ -0,0 +1,101 @@
+package accessible
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mahibulhaque/gofast/internal/steps"
+	"github.com/mahibulhaque/gofast/internal/tui/components/form"
+	"github.com/mahibulhaque/gofast/internal/tui/components/list"
+	"github.com/mahibulhaque/gofast/internal/tui/components/textinput"
+)
+
+// pipe returns the read end of a pipe holding input, like stdin when the
+// answers are piped in by a script
+func pipe(t *testing.T, input string) *os.File {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_, _ = io.WriteString(w, input)
+		w.Close()
+	}()
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestPipedAnswers(t *testing.T) {
+	step := steps.StepSchema{
+		Headers: "Pick one",
+		Options: []steps.Item{
+			{Flag: "chi", Title: "Chi"},
+			{Flag: "grpc", Title: "gRPC", Disabled: "not with react"},
+			{Flag: "gin", Title: "Gin"},
+		},
+	}
+
+	// Every first answer is rejected, the second one is taken
+	input := strings.Join([]string{
+		"", "demo",
+		"x", "2", "3",
+		"1,9", "3, 1",
+		"", "v2",
+	}, "\n") + "\n"
+	var out strings.Builder
+	p := New(pipe(t, input), &out)
+
+	var name textinput.Output
+	if err := p.Input("Project name", &name, nil); err != nil {
+		t.Fatal(err)
+	}
+	if name.Output != "demo" {
+		t.Errorf("name = %q, want demo", name.Output)
+	}
+
+	var framework list.Selection
+	if err := p.Select(step, &framework); err != nil {
+		t.Fatal(err)
+	}
+	if framework.Flag != "gin" {
+		t.Errorf("framework = %q, want gin", framework.Flag)
+	}
+
+	var features list.MultiSelection
+	if err := p.MultiSelect(step, &features, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(features.Flags, []string{"chi", "gin"}) {
+		t.Errorf("features = %v, want [chi gin]", features.Flags)
+	}
+
+	var vars form.Output
+	fields := []form.Field{
+		{Key: "port", Label: "Port", Value: "8080"},
+		{Key: "version", Label: "Version", Value: "v1"},
+	}
+	if err := p.Form("Variables", fields, &vars); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"port": "8080", "version": "v2"}; !reflect.DeepEqual(vars.Values, want) {
+		t.Errorf("vars = %v, want %v", vars.Values, want)
+	}
+
+	for _, message := range []string{"Please enter a value.", "'x' is not a number", "gRPC is unavailable", "'9' is not a number"} {
+		if !strings.Contains(out.String(), message) {
+			t.Errorf("output lacks %q:\n%s", message, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "\x1b") {
+		t.Errorf("output holds escape sequences:\n%q", out.String())
+	}
+
+	if err := p.Input("Another", &name, nil); !errors.Is(err, ErrInputEnded) {
+		t.Errorf("err = %v, want ErrInputEnded", err)
+	}
+}
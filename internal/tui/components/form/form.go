@@ -0,0 +1,147 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/mahibulhaque/gofast/internal/program"
+	"github.com/mahibulhaque/gofast/internal/tui/styles"
+)
+
+// Field is a single labelled text input of the form
+type Field struct {
+	Key         string
+	Label       string
+	Description string
+	Value       string
+	// Validate reports why a value is not accepted, it may be nil
+	Validate func(value string) error
+}
+
+// Output holds the confirmed value of every field, by key
+type Output struct {
+	Values map[string]string
+}
+
+type model struct {
+	fields  []Field
+	inputs  []textinput.Model
+	focused int
+	err     error
+	output  *Output
+	header  string
+	exit    *bool
+}
+
+// NewFormModel creates a form that edits every field, prefilled with its value
+func NewFormModel(fields []Field, output *Output, header string, project *program.Project) *model {
+	themeStyles := styles.CurrentTheme().S()
+
+	inputs := make([]textinput.Model, len(fields))
+	for i, field := range fields {
+		ti := textinput.New()
+		ti.Prompt = "> "
+		ti.CharLimit = 156
+		ti.SetWidth(60)
+		ti.SetValue(field.Value)
+		ti.SetStyles(themeStyles.TextInput)
+		inputs[i] = ti
+	}
+	if len(inputs) > 0 {
+		inputs[0].Focus()
+	}
+
+	return &model{
+		fields: fields,
+		inputs: inputs,
+		output: output,
+		header: themeStyles.Title.Render(header),
+		exit:   &project.Exit,
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			*m.exit = true
+			return m, tea.Quit
+		case "tab", "down":
+			return m, m.focus(m.focused + 1)
+		case "shift+tab", "up":
+			return m, m.focus(m.focused - 1)
+		case "enter":
+			if m.err = m.validate(m.focused); m.err != nil {
+				return m, nil
+			}
+			if m.focused < len(m.inputs)-1 {
+				return m, m.focus(m.focused + 1)
+			}
+			return m, m.submit()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
+	return m, cmd
+}
+
+// focus moves the cursor to the field at index, wrapping around
+func (m *model) focus(index int) tea.Cmd {
+	m.inputs[m.focused].Blur()
+	m.focused = (index + len(m.inputs)) % len(m.inputs)
+	return m.inputs[m.focused].Focus()
+}
+
+func (m *model) validate(index int) error {
+	if m.fields[index].Validate == nil {
+		return nil
+	}
+	return m.fields[index].Validate(strings.TrimSpace(m.inputs[index].Value()))
+}
+
+// submit stores every value once all of them are valid, otherwise the
+// first invalid field is focused
+func (m *model) submit() tea.Cmd {
+	for i := range m.inputs {
+		if m.err = m.validate(i); m.err != nil {
+			return m.focus(i)
+		}
+	}
+
+	m.output.Values = make(map[string]string, len(m.fields))
+	for i, field := range m.fields {
+		m.output.Values[field.Key] = strings.TrimSpace(m.inputs[i].Value())
+	}
+	return tea.Quit
+}
+
+func (m *model) View() string {
+	theme := styles.CurrentTheme()
+
+	rows := []string{m.header, ""}
+	for i, field := range m.fields {
+		label := theme.S().Text.Bold(true).Render(field.Label)
+		if i == m.focused {
+			label = theme.S().TextSelected.Bold(true).Render(field.Label)
+		}
+		rows = append(rows,
+			label,
+			theme.S().Muted.Render(field.Description),
+			m.inputs[i].View(),
+			"",
+		)
+	}
+	if m.err != nil {
+		rows = append(rows, theme.S().Error.Render(m.err.Error()), "")
+	}
+	rows = append(rows, theme.S().Muted.Render("tab / ↓ (next) • shift+tab / ↑ (previous) • enter (confirm) • esc / ctrl+c (quit)"), "\n")
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
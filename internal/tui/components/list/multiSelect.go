@@ -28,6 +28,8 @@ type MultiModel struct {
 	project   *program.Project
 	exit     *bool
 	keyMap    multiKeyMap
+	validate  func(flags []string) error
+	err       error
 }
 
 type multiKeyMap struct {
@@ -71,6 +73,7 @@ func NewMultiListModel(items []steps.Item, selection *MultiSelection, header str
 			title:       item.Title,
 			description: item.Desc,
 			flag:        item.Flag,
+			disabled:    item.Disabled,
 		}
 	}
 
@@ -144,6 +147,25 @@ func (d multiItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		checkbox = "[x]"
 	}
 
+	// Disabled items explain why they cannot be chosen instead of
+	// describing themselves
+	if reason := item.Disabled(); reason != "" {
+		cursor := "   "
+		if index == m.Index() {
+			cursor = "▶  "
+		}
+		titleStyle := d.theme.S().Subtle.
+			Padding(0, 1)
+		descStyle := d.theme.S().Subtle.
+			Padding(0, 1)
+
+		s.WriteString(titleStyle.Render(cursor + "[-] " + title))
+		s.WriteString("\n")
+		s.WriteString(descStyle.Render("  " + reason))
+		io.WriteString(w, s.String())
+		return
+	}
+
 	// Selected row (cursor on it)
 	if index == m.Index() {
 		titleStyle := d.theme.S().TextSelected.
@@ -198,7 +220,11 @@ func (m *MultiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.keyMap.toggle):
 			idx := m.list.Index()
+			if li, ok := m.list.SelectedItem().(ListItem); ok && li.Disabled() != "" {
+				return m, nil
+			}
 			m.selection.Selected[idx] = !m.selection.Selected[idx]
+			m.err = nil
 			return m, nil
 
 		case key.Matches(msg, m.keyMap.confirm):
@@ -214,6 +240,11 @@ func (m *MultiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+			if m.validate != nil {
+				if m.err = m.validate(flags); m.err != nil {
+					return m, nil
+				}
+			}
 			m.selection.Choices = choices
 			m.selection.Flags = flags
 			m.selection.Confirmed = true
@@ -239,9 +270,18 @@ func (m *MultiModel) View() string {
 
 	// Inject help controls footer
 	help := theme.S().Text.Render("\n" + m.list.Help.View(m.keyMap))
+	if m.err != nil {
+		help = theme.S().Error.Render("\n"+m.err.Error()) + help
+	}
 	return theme.S().Base.Render(view + help)
 }
 
+// SetValidate sets a check run on confirmation, the selection is only
+// confirmed once it returns nil
+func (m *MultiModel) SetValidate(validate func(flags []string) error) {
+	m.validate = validate
+}
+
 // NewMultiSelectFromStep constructs a MultiModel from a step schema
 func NewMultiSelectFromStep(step steps.StepSchema, selection *MultiSelection, project *program.Project) *MultiModel {
 	return NewMultiListModel(step.Options, selection, step.Headers, project)
@@ -17,12 +17,14 @@ type ListItem struct {
 	title       string
 	description string
 	flag        string
+	disabled    string
 }
 
 func (i ListItem) FilterValue() string { return i.title }
 func (i ListItem) Title() string       { return i.title }
 func (i ListItem) Description() string { return i.description }
 func (i ListItem) Flag() string        { return i.flag }
+func (i ListItem) Disabled() string    { return i.disabled }
 
 // Selection holds the user's choice
 type Selection struct {
@@ -41,6 +41,21 @@ func DefaultOpts() Opts {
 	}
 }
 
+// ThemeOpts returns the options drawing the logo in the colors of t, the
+// DefaultOpts for themes without logo colors
+func ThemeOpts(t *styles.Theme) Opts {
+	o := DefaultOpts()
+	if t.Logo == nil {
+		return o
+	}
+	o.FieldColor = t.Logo.Field
+	o.TitleColorA = t.Logo.TitleA
+	o.TitleColorB = t.Logo.TitleB
+	o.CharmColor = t.Logo.Brand
+	o.VersionColor = t.Logo.Version
+	return o
+}
+
 // Render renders the Gofast logo. Set the compact argument to true to render the narrow
 // version, intended for use in a sidebar.
 //
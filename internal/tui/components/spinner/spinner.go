@@ -63,3 +63,8 @@ func (m model) View() string {
 	}
 	return str
 }
+
+// Quitting reports whether the user asked to quit while the spinner was shown
+func (m model) Quitting() bool {
+	return m.quitting
+}
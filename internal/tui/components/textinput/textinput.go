@@ -32,6 +32,12 @@ type model struct {
 	output    *Output
 	header    string
 	exit      *bool
+
+	// validate is run on every change, an invalid value cannot be submitted
+	validate   func(value string) error
+	suggest    func(value string) string
+	inputErr   error
+	suggestion string
 }
 
 func sanitizeTextInput(input string) error {
@@ -67,6 +73,30 @@ func NewTextInputModel(output *Output, header string, program *program.Project)
 	}
 }
 
+// WithValidate returns the model checking the value as it is typed. The
+// error of validate is shown below the input, together with the suggestion
+// of suggest, which tab accepts. suggest may be nil
+func (m model) WithValidate(validate func(value string) error, suggest func(value string) string) model {
+	m.validate = validate
+	m.suggest = suggest
+	return m
+}
+
+// check validates the current value, an empty value is only reported when
+// submitting it
+func (m *model) check() {
+	m.inputErr = nil
+	m.suggestion = ""
+
+	value := m.textInput.Value()
+	if m.validate == nil || value == "" {
+		return
+	}
+	if m.inputErr = m.validate(value); m.inputErr != nil && m.suggest != nil {
+		m.suggestion = m.suggest(value)
+	}
+}
+
 func CreateErrorInputModel(err error) model {
 
 	themeStyles := styles.CurrentTheme().S()
@@ -100,11 +130,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
+			if m.validate != nil {
+				m.check()
+				if m.inputErr != nil {
+					return m, nil
+				}
+			}
 			if len(m.textInput.Value()) > 1 {
 				m.output.update(m.textInput.Value())
 				return m, tea.Quit
 			}
-		case "ctrl+c", "esc", "q":
+		case "tab":
+			if m.suggestion != "" {
+				m.textInput.SetValue(m.suggestion)
+				m.textInput.CursorEnd()
+				m.check()
+				return m, nil
+			}
+		case "ctrl+c", "esc":
 			*m.exit = true
 			return m, tea.Quit
 		}
@@ -114,7 +157,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
+	previous := m.textInput.Value()
 	m.textInput, cmd = m.textInput.Update(msg)
+	if m.textInput.Value() != previous {
+		m.check()
+	}
 	return m, cmd
 }
 
@@ -129,7 +176,16 @@ func (m model) View() string {
 		Width(80).
 		Render(inputView)
 
-	content := lipgloss.JoinVertical(lipgloss.Left, m.header, borderedInput, "\n")
+	lines := []string{m.header, borderedInput}
+	if m.inputErr != nil {
+		lines = append(lines, theme.S().Error.Render(m.inputErr.Error()))
+		if m.suggestion != "" {
+			lines = append(lines, theme.S().Muted.Render(fmt.Sprintf("Did you mean '%s'? Press tab to use it", m.suggestion)))
+		}
+	}
+	lines = append(lines, "\n")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
 	return content
 }
 
@@ -0,0 +1,156 @@
+package styles
+
+import (
+	"image/color"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// NoColorThemeName is the theme used when the NO_COLOR environment variable
+// is set
+const NoColorThemeName = "no-color"
+
+// NewLightTheme returns a theme for terminals with a light background
+func NewLightTheme() *Theme {
+	t := &Theme{
+		ThemeName: "light",
+		IsDark:    false,
+
+		Primary:   ParseHex("#5a3fd9"),
+		Secondary: ParseHex("#c2185b"),
+		Tertiary:  ParseHex("#00796b"),
+		Accent:    ParseHex("#b25e00"),
+
+		// Backgrounds
+		BgBase:        ParseHex("#fafafa"),
+		BgBaseLighter: ParseHex("#ffffff"),
+		BgSubtle:      ParseHex("#eeeeee"),
+		BgOverlay:     ParseHex("#e0e0e0"),
+
+		// Foregrounds
+		FgBase:      ParseHex("#262626"),
+		FgMuted:     ParseHex("#5c5c66"),
+		FgHalfMuted: ParseHex("#4a4a52"),
+		FgSubtle:    ParseHex("#8a8a94"),
+		FgSelected:  ParseHex("#000000"),
+
+		// Borders
+		Border:      ParseHex("#d0d0d0"),
+		BorderFocus: ParseHex("#5a3fd9"),
+
+		// Status
+		Success: ParseHex("#1b7f3b"),
+		Error:   ParseHex("#c62828"),
+		Warning: ParseHex("#a35a00"),
+		Info:    ParseHex("#1565c0"),
+
+		// Colors
+		White: ParseHex("#262626"),
+
+		Logo: &LogoColors{
+			Field:   ParseHex("#5a3fd9"),
+			TitleA:  ParseHex("#c2185b"),
+			TitleB:  ParseHex("#5a3fd9"),
+			Brand:   ParseHex("#c2185b"),
+			Version: ParseHex("#5c5c66"),
+		},
+	}
+
+	t.TextSelection = lipgloss.NewStyle().Foreground(ParseHex("#ffffff")).Background(t.Primary)
+
+	return t
+}
+
+// NewHighContrastTheme returns a theme with the strongest contrast on dark
+// terminals
+func NewHighContrastTheme() *Theme {
+	t := &Theme{
+		ThemeName: "high-contrast",
+		IsDark:    true,
+
+		Primary:   ParseHex("#ffff00"),
+		Secondary: ParseHex("#00ffff"),
+		Tertiary:  ParseHex("#ffffff"),
+		Accent:    ParseHex("#ffff00"),
+
+		// Backgrounds
+		BgBase:        ParseHex("#000000"),
+		BgBaseLighter: ParseHex("#000000"),
+		BgSubtle:      ParseHex("#1a1a1a"),
+		BgOverlay:     ParseHex("#333333"),
+
+		// Foregrounds
+		FgBase:      ParseHex("#ffffff"),
+		FgMuted:     ParseHex("#e0e0e0"),
+		FgHalfMuted: ParseHex("#f0f0f0"),
+		FgSubtle:    ParseHex("#c0c0c0"),
+		FgSelected:  ParseHex("#ffff00"),
+
+		// Borders
+		Border:      ParseHex("#ffffff"),
+		BorderFocus: ParseHex("#ffff00"),
+
+		// Status
+		Success: ParseHex("#00ff00"),
+		Error:   ParseHex("#ff5555"),
+		Warning: ParseHex("#ffff00"),
+		Info:    ParseHex("#00ffff"),
+
+		// Colors
+		White: ParseHex("#ffffff"),
+
+		Logo: &LogoColors{
+			Field:   ParseHex("#ffffff"),
+			TitleA:  ParseHex("#ffff00"),
+			TitleB:  ParseHex("#00ffff"),
+			Brand:   ParseHex("#ffff00"),
+			Version: ParseHex("#ffffff"),
+		},
+	}
+
+	t.TextSelection = lipgloss.NewStyle().Foreground(ParseHex("#000000")).Background(t.Primary)
+
+	return t
+}
+
+// NewNoColorTheme returns a theme without any color, emphasis is kept
+func NewNoColorTheme() *Theme {
+	var none color.Color = lipgloss.NoColor{}
+
+	t := &Theme{
+		ThemeName: NoColorThemeName,
+		IsDark:    true,
+
+		Primary:   none,
+		Secondary: none,
+		Tertiary:  none,
+		Accent:    none,
+
+		BgBase:        none,
+		BgBaseLighter: none,
+		BgSubtle:      none,
+		BgOverlay:     none,
+
+		FgBase:      none,
+		FgMuted:     none,
+		FgHalfMuted: none,
+		FgSubtle:    none,
+		FgSelected:  none,
+
+		Border:      none,
+		BorderFocus: none,
+
+		Success: none,
+		Error:   none,
+		Warning: none,
+		Info:    none,
+
+		White: none,
+
+		Logo: &LogoColors{Field: none, TitleA: none, TitleB: none, Brand: none, Version: none},
+	}
+
+	t.TextSelection = lipgloss.NewStyle().Reverse(true)
+
+	return t
+}
@@ -0,0 +1,214 @@
+package styles
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/mahibulhaque/gofast/internal/toml"
+)
+
+// ThemeFileExtensions are the formats a theme file may be written in. Both
+// hold the same flat keys, e.g. in TOML
+//
+//	name = "solarized"
+//	base = "light"
+//	primary = "#268bd2"
+//	error = "#dc322f"
+//
+// Colors that are left out are taken from the base theme, charmtone by
+// default.
+var ThemeFileExtensions = []string{".json", ".toml"}
+
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// themeColors maps the keys of a theme file to the colors of a theme
+func themeColors(t *Theme) map[string]*color.Color {
+	return map[string]*color.Color{
+		"primary":         &t.Primary,
+		"secondary":       &t.Secondary,
+		"tertiary":        &t.Tertiary,
+		"accent":          &t.Accent,
+		"bg_base":         &t.BgBase,
+		"bg_base_lighter": &t.BgBaseLighter,
+		"bg_subtle":       &t.BgSubtle,
+		"bg_overlay":      &t.BgOverlay,
+		"fg_base":         &t.FgBase,
+		"fg_muted":        &t.FgMuted,
+		"fg_half_muted":   &t.FgHalfMuted,
+		"fg_subtle":       &t.FgSubtle,
+		"fg_selected":     &t.FgSelected,
+		"border":          &t.Border,
+		"border_focus":    &t.BorderFocus,
+		"success":         &t.Success,
+		"error":           &t.Error,
+		"warning":         &t.Warning,
+		"info":            &t.Info,
+		"white":           &t.White,
+	}
+}
+
+// IsThemeFile reports whether name has the extension of a theme file
+func IsThemeFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, allowed := range ThemeFileExtensions {
+		if ext == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadThemeFile reads the theme at path. Its name defaults to the file name
+// without the extension. Base themes are looked up in m
+func (m *Manager) LoadThemeFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read theme: %w", err)
+	}
+
+	var values map[string]string
+	switch filepath.Ext(path) {
+	case ".json":
+		values, err = parseJSONTheme(data)
+	case ".toml":
+		// Theme files are read like config.toml, arrays have no use here
+		values = make(map[string]string)
+		err = toml.Decode(bytes.NewReader(data), func(key string, value toml.Value) error {
+			if value.IsArray {
+				return fmt.Errorf("%s must be a string", key)
+			}
+			values[key] = value.String
+			return nil
+		})
+	default:
+		err = fmt.Errorf("unknown format, use one of %s", strings.Join(ThemeFileExtensions, ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	theme, err := m.newFileTheme(name, values)
+	if err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+	}
+	return theme, nil
+}
+
+// LoadThemeDir registers every valid theme file of dir and returns the
+// errors of the others, joined. A missing dir is not an error
+func (m *Manager) LoadThemeDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("could not read themes: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || !IsThemeFile(entry.Name()) {
+			continue
+		}
+		theme, err := m.LoadThemeFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.Register(theme)
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) newFileTheme(name string, values map[string]string) (*Theme, error) {
+	if values["name"] != "" {
+		name = values["name"]
+	}
+	baseName := values["base"]
+	if baseName == "" {
+		baseName = "charmtone"
+	}
+	base, ok := m.themes[baseName]
+	if !ok {
+		return nil, fmt.Errorf("unknown base theme %s", baseName)
+	}
+
+	// The copy must not share the styles built for the base theme
+	theme := *base
+	theme.ThemeName = name
+	theme.styles = nil
+	if base.Logo != nil {
+		logo := *base.Logo
+		theme.Logo = &logo
+	}
+
+	colors := themeColors(&theme)
+	for key, value := range values {
+		switch key {
+		case "name", "base":
+			continue
+		case "dark":
+			dark, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("dark must be true or false")
+			}
+			theme.IsDark = dark
+			continue
+		}
+
+		target, ok := colors[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %s", key)
+		}
+		if !hexColorPattern.MatchString(value) {
+			return nil, fmt.Errorf("%s: '%s' is not a #rrggbb color", key, value)
+		}
+		*target = lipgloss.Color(value)
+	}
+
+	// The logo of the base theme is kept unless the colors it is drawn
+	// with change
+	if values["primary"] != "" || values["secondary"] != "" || values["fg_muted"] != "" {
+		theme.Logo = &LogoColors{
+			Field:   theme.Primary,
+			TitleA:  theme.Secondary,
+			TitleB:  theme.Primary,
+			Brand:   theme.Secondary,
+			Version: theme.FgMuted,
+		}
+	}
+	theme.TextSelection = lipgloss.NewStyle().Foreground(theme.FgSelected).Background(theme.Primary)
+
+	return &theme, nil
+}
+
+func parseJSONTheme(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case bool:
+			values[key] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("%s must be a string", key)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,53 @@
+package styles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+func TestLoadThemeFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"solarized.toml": `# solarized
+name = "solarized" # shown in gofast themes
+dark = false
+primary = '#268bd2'
+`,
+		"solarized.json": `{"name": "solarized", "dark": false, "primary": "#268bd2"}`,
+		"array.toml":     `primary = ["#268bd2"]`,
+		"quote.toml":     `primary = "#268bd2`,
+		"color.toml":     `primary = "blue"`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewManager()
+	for _, name := range []string{"solarized.toml", "solarized.json"} {
+		theme, err := m.LoadThemeFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if theme.ThemeName != "solarized" || theme.IsDark || theme.Primary != lipgloss.Color("#268bd2") {
+			t.Errorf("%s: theme = %s, dark %t, primary %v", name, theme.ThemeName, theme.IsDark, theme.Primary)
+		}
+	}
+
+	errs := map[string]string{
+		"array.toml": "line 1: primary must be a string",
+		"quote.toml": `line 1: primary: unterminated string "#268bd2`,
+		"color.toml": "primary: 'blue' is not a #rrggbb color",
+	}
+	for name, want := range errs {
+		_, err := m.LoadThemeFile(filepath.Join(dir, name))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want it to contain %q", name, err, want)
+		}
+	}
+}
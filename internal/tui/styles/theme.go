@@ -3,6 +3,7 @@ package styles
 import (
 	"fmt"
 	"image/color"
+	"sort"
 	"strings"
 	"time"
 
@@ -52,9 +53,22 @@ type Theme struct {
 
 	TextSelection lipgloss.Style
 
+	// Logo colors the title art, the colors of logo.DefaultOpts are used
+	// when nil
+	Logo *LogoColors
+
 	styles *Styles
 }
 
+// LogoColors are the colors of the title art
+type LogoColors struct {
+	Field   color.Color
+	TitleA  color.Color
+	TitleB  color.Color
+	Brand   color.Color
+	Version color.Color
+}
+
 type Styles struct {
 	Base         lipgloss.Style
 	SelectedBase lipgloss.Style
@@ -106,6 +120,8 @@ func (t *Theme) buildStyles() *Styles {
 
 		Error: base.Foreground(t.Error),
 
+		Warning: base.Foreground(t.Warning),
+
 		Info: base.Foreground(t.Info),
 
 		TextInput: textinput.Styles{
@@ -170,6 +186,9 @@ func NewManager() *Manager {
 	t := NewCharmtoneTheme()
 
 	m.Register(t)
+	m.Register(NewLightTheme())
+	m.Register(NewHighContrastTheme())
+	m.Register(NewNoColorTheme())
 	m.current = m.themes[t.ThemeName]
 
 	return m
@@ -188,14 +207,22 @@ func (m *Manager) SetTheme(name string) error {
 		m.current = theme
 		return nil
 	}
-	return fmt.Errorf("theme %s not found", name)
+	return fmt.Errorf("theme %s not found. Available themes: %s", name, strings.Join(m.List(), ", "))
 }
 
+// Theme returns the registered theme called name
+func (m *Manager) Theme(name string) (*Theme, bool) {
+	theme, ok := m.themes[name]
+	return theme, ok
+}
+
+// List returns the names of the registered themes, sorted
 func (m *Manager) List() []string {
 	names := make([]string, 0, len(m.themes))
 	for name := range m.themes {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -249,6 +276,15 @@ func ForegroundGrad(input string, bold bool, color1, color2 color.Color) []strin
 
 	t := CurrentTheme()
 
+	// There is nothing to blend without colors
+	if isNoColor(color1) || isNoColor(color2) {
+		style := t.S().Base
+		if bold {
+			style = style.Bold(true)
+		}
+		return []string{style.Render(input)}
+	}
+
 	if len(input) == 1 {
 		style := t.S().Base.Foreground(color1)
 		if bold {
@@ -348,3 +384,8 @@ func blendColors(size int, stops ...color.Color) []color.Color {
 
 	return blended
 }
+
+func isNoColor(c color.Color) bool {
+	_, ok := c.(lipgloss.NoColor)
+	return ok
+}
@@ -0,0 +1,249 @@
+package vars
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Vars holds the values templates use for ports, environment settings and
+// names. They are available to every template as .Vars, e.g. {{ .Vars.Port }}.
+type Vars struct {
+	Port           int
+	AppEnv         string
+	DBName         string
+	DBUser         string
+	DBPassword     string
+	DBRootPassword string
+	GoImageTag     string
+	VitePort       int
+	WebPort        int
+}
+
+// Definition documents a single variable and how to set it
+type Definition struct {
+	Key         string
+	Default     string
+	Description string
+
+	get func(v *Vars) string
+	set func(v *Vars, value string) error
+}
+
+// Definitions lists every variable in the order they are shown to the user
+var Definitions = []Definition{
+	{
+		Key:         "port",
+		Default:     "8080",
+		Description: "Port the server listens on (PORT)",
+		get:         func(v *Vars) string { return strconv.Itoa(v.Port) },
+		set:         func(v *Vars, value string) (err error) { v.Port, err = parsePort(value); return },
+	},
+	{
+		Key:         "app_env",
+		Default:     "local",
+		Description: "Environment the application runs in (APP_ENV)",
+		get:         func(v *Vars) string { return v.AppEnv },
+		set:         func(v *Vars, value string) (err error) { v.AppEnv, err = parseWord(value); return },
+	},
+	{
+		Key:         "db_name",
+		Default:     "gofast",
+		Description: "Name of the database (DB_DATABASE)",
+		get:         func(v *Vars) string { return v.DBName },
+		set:         func(v *Vars, value string) (err error) { v.DBName, err = parseWord(value); return },
+	},
+	{
+		Key:         "db_user",
+		Default:     "user",
+		Description: "Database user (DB_USERNAME)",
+		get:         func(v *Vars) string { return v.DBUser },
+		set:         func(v *Vars, value string) (err error) { v.DBUser, err = parseWord(value); return },
+	},
+	{
+		Key:         "db_password",
+		Default:     "password1234",
+		Description: "Password of the database user (DB_PASSWORD)",
+		get:         func(v *Vars) string { return v.DBPassword },
+		set:         func(v *Vars, value string) (err error) { v.DBPassword, err = parseSecret(value); return },
+	},
+	{
+		Key:         "db_root_password",
+		Default:     "admin1234",
+		Description: "Password of the MySQL root user (DB_ROOT_PASSWORD)",
+		get:         func(v *Vars) string { return v.DBRootPassword },
+		set:         func(v *Vars, value string) (err error) { v.DBRootPassword, err = parseSecret(value); return },
+	},
+	{
+		Key:         "go_image_tag",
+		Default:     "1.25.0-alpine",
+		Description: "Tag of the golang image the Dockerfile builds with",
+		get:         func(v *Vars) string { return v.GoImageTag },
+		set:         func(v *Vars, value string) (err error) { v.GoImageTag, err = parseWord(value); return },
+	},
+	{
+		Key:         "vite_port",
+		Default:     "5173",
+		Description: "Port the Vite frontend is served on",
+		get:         func(v *Vars) string { return strconv.Itoa(v.VitePort) },
+		set:         func(v *Vars, value string) (err error) { v.VitePort, err = parsePort(value); return },
+	},
+	{
+		Key:         "web_port",
+		Default:     "3000",
+		Description: "Port the web binary serves the frontend on (WEB_PORT)",
+		get:         func(v *Vars) string { return strconv.Itoa(v.WebPort) },
+		set:         func(v *Vars, value string) (err error) { v.WebPort, err = parsePort(value); return },
+	},
+}
+
+// Defaults returns the documented default of every variable
+func Defaults() Vars {
+	var v Vars
+	for _, d := range Definitions {
+		if err := d.set(&v, d.Default); err != nil {
+			panic(fmt.Sprintf("invalid default for %s: %v", d.Key, err))
+		}
+	}
+	return v
+}
+
+// Keys returns the key of every variable
+func Keys() []string {
+	keys := make([]string, len(Definitions))
+	for i, d := range Definitions {
+		keys[i] = d.Key
+	}
+	return keys
+}
+
+// Lookup returns the definition of key
+func Lookup(key string) (Definition, bool) {
+	for _, d := range Definitions {
+		if d.Key == key {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Get returns the value of key as a string
+func (v *Vars) Get(key string) (string, error) {
+	d, ok := Lookup(key)
+	if !ok {
+		return "", unknownKey(key)
+	}
+	return d.get(v), nil
+}
+
+// Set parses value and assigns it to key
+func (v *Vars) Set(key string, value string) error {
+	d, ok := Lookup(key)
+	if !ok {
+		return unknownKey(key)
+	}
+	// A rejected value keeps the previous one
+	next := *v
+	if err := d.set(&next, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	*v = next
+	return nil
+}
+
+// SetAssignment applies a "key=value" assignment, as passed to --set
+func (v *Vars) SetAssignment(assignment string) error {
+	key, value, ok := strings.Cut(assignment, "=")
+	if !ok {
+		return fmt.Errorf("'%s' is not a key=value assignment", assignment)
+	}
+	return v.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+}
+
+// SetMap applies every key of values, in a stable order
+func (v *Vars) SetMap(values map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := v.Set(key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load applies the "key=value" lines read from r. Empty lines and lines
+// starting with # are ignored.
+func (v *Vars) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := v.SetAssignment(text); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Changed returns a "key=value" assignment for every variable that differs
+// from its default
+func (v *Vars) Changed() []string {
+	defaults := Defaults()
+
+	var changed []string
+	for _, d := range Definitions {
+		if value := d.get(v); value != d.get(&defaults) {
+			changed = append(changed, d.Key+"="+value)
+		}
+	}
+	return changed
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown template variable '%s'. Allowed keys: %s", key, strings.Join(Keys(), ", "))
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("'%s' is not a port between 1 and 65535", value)
+	}
+	return port, nil
+}
+
+// parseWord accepts values that can be used unquoted in .env files and
+// Dockerfiles
+func parseWord(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("value must not be empty")
+	}
+	if strings.ContainsFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("._-", r))
+	}) {
+		return "", fmt.Errorf("'%s' may only contain letters, digits, '.', '_' and '-'", value)
+	}
+	return value, nil
+}
+
+// parseSecret accepts any printable value without whitespace or quotes
+func parseSecret(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("value must not be empty")
+	}
+	if strings.ContainsFunc(value, func(r rune) bool {
+		return r <= ' ' || r == 0x7f || strings.ContainsRune("\"'`$#\\", r)
+	}) {
+		return "", fmt.Errorf("value must not contain whitespace, quotes, '$', '#' or '\\'")
+	}
+	return value, nil
+}
@@ -0,0 +1,132 @@
+package vars
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		// err is part of the expected error, empty when the value is valid
+		err string
+	}{
+		{key: "port", value: "3000"},
+		{key: "port", value: "65535"},
+		{key: "port", value: "0", err: "'0' is not a port between 1 and 65535"},
+		{key: "port", value: "65536", err: "'65536' is not a port between 1 and 65535"},
+		{key: "port", value: "http", err: "'http' is not a port between 1 and 65535"},
+		{key: "vite_port", value: "-1", err: "invalid value for vite_port"},
+		{key: "app_env", value: "staging-2"},
+		{key: "app_env", value: "", err: "must not be empty"},
+		{key: "db_name", value: "my db", err: "may only contain letters, digits"},
+		{key: "go_image_tag", value: "1.25.0-alpine"},
+		{key: "db_password", value: "s3cr3t!%"},
+		{key: "db_password", value: "pass word", err: "must not contain whitespace"},
+		{key: "db_root_password", value: "pa$$", err: "must not contain whitespace"},
+		{key: "db_password", value: `"quoted"`, err: "must not contain whitespace"},
+		{key: "colour", value: "blue", err: "unknown template variable 'colour'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			v := Defaults()
+			err := v.Set(tt.key, tt.value)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				if got, _ := v.Get(tt.key); got != tt.value {
+					t.Errorf("%s = %q, want %q", tt.key, got, tt.value)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.err)
+			}
+			if v != Defaults() {
+				t.Errorf("a rejected value changed the vars: %+v", v)
+			}
+		})
+	}
+}
+
+func TestSetAssignment(t *testing.T) {
+	v := Defaults()
+	if err := v.SetAssignment(" port = 9000 "); err != nil {
+		t.Fatal(err)
+	}
+	if v.Port != 9000 {
+		t.Errorf("port = %d, want 9000", v.Port)
+	}
+
+	if err := v.SetAssignment("port"); err == nil || !strings.Contains(err.Error(), "'port' is not a key=value assignment") {
+		t.Errorf("err = %v, want a key=value error", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		// err is part of the expected error, empty when the file is valid
+		err     string
+		changed []string
+	}{
+		{
+			name:    "assignments",
+			input:   "# ports\nport=3000\n\n  web_port = 4000\ndb_name=shop\n",
+			changed: []string{"port=3000", "db_name=shop", "web_port=4000"},
+		},
+		{
+			name:  "missing equals sign",
+			input: "port=3000\napp_env\n",
+			err:   "line 2: 'app_env' is not a key=value assignment",
+		},
+		{
+			name:  "unknown key",
+			input: "\n\ncolour=blue\n",
+			err:   "line 3: unknown template variable 'colour'",
+		},
+		{
+			name:  "invalid port",
+			input: "port=80a\n",
+			err:   "line 1: invalid value for port: '80a' is not a port between 1 and 65535",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Defaults()
+			err := v.Load(strings.NewReader(tt.input))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("err = %v, want it to contain %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Changed(); !reflect.DeepEqual(got, tt.changed) {
+				t.Errorf("changed = %v, want %v", got, tt.changed)
+			}
+		})
+	}
+}
+
+func TestSetMap(t *testing.T) {
+	v := Defaults()
+	if err := v.SetMap(map[string]string{"port": "3000", "vite_port": "3001"}); err != nil {
+		t.Fatal(err)
+	}
+	if v.Port != 3000 || v.VitePort != 3001 {
+		t.Errorf("ports = %d, %d, want 3000, 3001", v.Port, v.VitePort)
+	}
+
+	if err := v.SetMap(map[string]string{"port": "1", "nope": "x"}); err == nil {
+		t.Error("err = nil, want an unknown key error")
+	}
+}
@@ -0,0 +1,342 @@
+// Package gofast generates Go projects from the same templates as the gofast
+// CLI, for programs that want to embed project generation instead of
+// shelling out to the command line tool.
+//
+// Generate never changes the working directory, keeps no global state and
+// writes nothing to the terminal. Files are written through Options.FS and
+// external commands (go, gofmt, git and npm) are run through Options.Runner,
+// so both can be replaced, e.g. to render a project into memory:
+//
+//	result, err := gofast.Generate(ctx, gofast.Options{
+//		Name:         "github.com/acme/api",
+//		Framework:    gofast.Chi,
+//		DBDriver:     gofast.Postgres,
+//		Features:     []gofast.Feature{gofast.FeatureDocker},
+//		FS:           gofast.NewMemFS(),
+//		SkipCommands: true,
+//	})
+package gofast
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/mahibulhaque/gofast/internal/executor"
+	"github.com/mahibulhaque/gofast/internal/flags"
+	"github.com/mahibulhaque/gofast/internal/modules"
+	"github.com/mahibulhaque/gofast/internal/program"
+	"github.com/mahibulhaque/gofast/internal/registry"
+	"github.com/mahibulhaque/gofast/internal/vars"
+)
+
+type (
+	// Framework is the HTTP framework, gRPC or CLI the project is built on.
+	Framework = flags.Framework
+	// Database is the database driver wired into the project.
+	Database = flags.Database
+	// Git selects whether a git repository is initialized.
+	Git = flags.Git
+	// PackageManager installs and runs the frontend.
+	PackageManager = flags.PackageManager
+	// Feature is an advanced feature added to the project.
+	Feature string
+)
+
+const (
+	Chi             = flags.Chi
+	Gin             = flags.Gin
+	Fiber           = flags.Fiber
+	GorillaMux      = flags.GorillaMux
+	HttpRouter      = flags.HttpRouter
+	StandardLibrary = flags.StandardLibrary
+	Echo            = flags.Echo
+	Grpc            = flags.Grpc
+	Cli             = flags.Cli
+)
+
+const (
+	MySql    = flags.MySql
+	Postgres = flags.Postgres
+	Sqlite   = flags.Sqlite
+	Mongo    = flags.Mongo
+	Redis    = flags.Redis
+	None     = flags.None
+)
+
+const (
+	GitCommit Git = flags.Commit
+	GitStage  Git = flags.Stage
+	GitSkip   Git = flags.Skip
+)
+
+const (
+	Npm  = flags.Npm
+	Pnpm = flags.Pnpm
+	Yarn = flags.Yarn
+	Bun  = flags.Bun
+)
+
+const (
+	FeatureGitHubAction Feature = Feature(flags.GoProjectWorkflow)
+	FeatureWebsocket    Feature = Feature(flags.Websocket)
+	FeatureReact        Feature = Feature(flags.React)
+	FeatureVue          Feature = Feature(flags.Vue)
+	FeatureSvelte       Feature = Feature(flags.Svelte)
+	FeatureSolid        Feature = Feature(flags.Solid)
+	FeatureDocker       Feature = Feature(flags.Docker)
+	FeatureConnect      Feature = Feature(flags.Connect)
+	FeatureGraphQL      Feature = Feature(flags.GraphQL)
+	FeatureWorker       Feature = Feature(flags.Worker)
+	FeatureWeb          Feature = Feature(flags.Web)
+	FeatureEmbed        Feature = Feature(flags.Embed)
+	FeatureHtmx         Feature = Feature(flags.Htmx)
+)
+
+type (
+	// FS is the filesystem a project is written to.
+	FS = program.FS
+	// OSFS writes to the local disk.
+	OSFS = program.OSFS
+	// MemFS keeps a generated project in memory.
+	MemFS = program.MemFS
+	// MemFile is a file or directory held by a MemFS.
+	MemFile = program.MemFile
+	// Runner runs a single external command in a directory.
+	Runner = executor.CommandRunner
+	// ResolveError is returned by Generate when the features cannot be
+	// generated together, or a tool they need is missing.
+	ResolveError = registry.ResolveError
+)
+
+// NewMemFS returns an empty in-memory filesystem.
+func NewMemFS() *MemFS {
+	return program.NewMemFS()
+}
+
+// Options mirror the flags of "gofast create".
+type Options struct {
+	// Name is the module path of the project, e.g. github.com/acme/api.
+	// The project directory is named after its last element.
+	Name string
+	// Framework defaults to StandardLibrary.
+	Framework Framework
+	// DBDriver defaults to None.
+	DBDriver Database
+	Features []Feature
+	// Git defaults to GitSkip.
+	Git Git
+	// PackageManager is used in the Makefile, the Dockerfile and to install
+	// the frontend. It defaults to Npm.
+	PackageManager PackageManager
+	// Vars overrides template variables by key, e.g. "port": "3000". Every
+	// variable that is not set keeps its default, see VarKeys.
+	Vars map[string]string
+
+	// Dir is the directory the project directory is created in. It is
+	// interpreted by FS and defaults to the root of FS.
+	Dir string
+	// FS defaults to the local disk.
+	FS FS
+	// Runner defaults to running local processes with a five minute
+	// timeout per command.
+	Runner Runner
+	// SkipCommands only renders the files without running go, gofmt, git
+	// or npm. The generated go.mod then has no requirements yet, run
+	// "go mod tidy" in the project to resolve them.
+	SkipCommands bool
+	// InstallFrontend installs the dependencies of the frontend once it is
+	// written. It is ignored when SkipCommands is set.
+	InstallFrontend bool
+}
+
+// Result describes a generated project.
+type Result struct {
+	// Path is the project directory, as passed to FS.
+	Path string
+	// Files lists every file written through FS, relative to Path and
+	// slash separated, in lexical order. Files created by external
+	// commands such as go.sum are not included.
+	Files []string
+	// Notes explain how the features behave with the chosen framework and
+	// driver, e.g. that a Docker image of a SQLite project is built with cgo.
+	Notes []string
+}
+
+// Validate reports the first option that gofast cannot generate.
+func (o Options) Validate() error {
+	if !modules.ValidateModuleName(o.Name) {
+		return fmt.Errorf("'%s' is not a valid module name", o.Name)
+	}
+
+	if o.Framework != "" {
+		if _, err := registry.ParseFramework(o.Framework.String()); err != nil {
+			return err
+		}
+	}
+
+	if o.DBDriver != "" {
+		if _, err := registry.ParseDriver(o.DBDriver.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, feature := range o.Features {
+		if _, err := registry.ParseFeature(string(feature)); err != nil {
+			return err
+		}
+	}
+
+	if o.Git != "" {
+		if _, err := registry.ParseGit(o.Git.String()); err != nil {
+			return err
+		}
+	}
+
+	if o.PackageManager != "" {
+		var packageManager flags.PackageManager
+		if err := packageManager.Set(o.PackageManager.String()); err != nil {
+			return err
+		}
+	}
+
+	templateVars := vars.Defaults()
+	return templateVars.SetMap(o.Vars)
+}
+
+// Generate creates a project as described by opts.
+func Generate(ctx context.Context, opts Options) (Result, error) {
+	if err := opts.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	fsys := opts.FS
+	if fsys == nil {
+		fsys = OSFS{}
+	}
+	recorder := &recordingFS{FS: fsys}
+
+	runner := opts.Runner
+	if runner == nil {
+		runner = &executor.Runner{Timeout: executor.DefaultTimeout}
+	}
+
+	templateVars := vars.Defaults()
+	if err := templateVars.SetMap(opts.Vars); err != nil {
+		return Result{}, err
+	}
+
+	// Features may imply other features, need tools such as npm or not be
+	// available with the chosen framework and driver
+	features := make([]string, len(opts.Features))
+	for i, feature := range opts.Features {
+		features[i] = string(feature)
+	}
+	resolution, err := registry.Resolve(registry.Selection{
+		Framework:       orDefault(opts.Framework, StandardLibrary),
+		Driver:          orDefault(opts.DBDriver, None),
+		Features:        features,
+		PackageManager:  orDefault(opts.PackageManager, Npm),
+		SkipCommands:    opts.SkipCommands,
+		InstallFrontend: opts.InstallFrontend,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	project := &program.Project{
+		ProjectName:     opts.Name,
+		AbsolutePath:    opts.Dir,
+		ProjectType:     orDefault(opts.Framework, StandardLibrary),
+		DBDriver:        orDefault(opts.DBDriver, None),
+		GitOptions:      orDefault(opts.Git, GitSkip),
+		PackageManager:  orDefault(opts.PackageManager, Npm),
+		FrameworkMap:    make(map[flags.Framework]program.Framework),
+		DBDriverMap:     make(map[flags.Database]program.DBDriver),
+		AdvancedOptions: make(map[string]bool),
+		FS:              recorder,
+		Runner:          runner,
+		SkipCommands:    opts.SkipCommands,
+		InstallFrontend: opts.InstallFrontend,
+		Vars:            templateVars,
+	}
+	for _, feature := range resolution.Features {
+		project.AdvancedOptions[feature] = true
+	}
+
+	projectPath := filepath.Join(opts.Dir, modules.GetRootDir(opts.Name))
+	if err := project.CreateMainFile(ctx); err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Path:  projectPath,
+		Files: recorder.files(projectPath),
+		Notes: resolution.Notes,
+	}, nil
+}
+
+// VarKeys lists the keys accepted in Options.Vars.
+func VarKeys() []string {
+	return vars.Keys()
+}
+
+func orDefault[T ~string](value T, fallback T) T {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// recordingFS remembers the name of every file written through it
+type recordingFS struct {
+	FS
+
+	mu      sync.Mutex
+	written map[string]bool
+}
+
+func (r *recordingFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := r.FS.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.written == nil {
+		r.written = make(map[string]bool)
+	}
+	r.written[name] = true
+	return nil
+}
+
+func (r *recordingFS) Remove(name string) error {
+	if err := r.FS.Remove(name); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.written, name)
+	return nil
+}
+
+// files returns the written files relative to dir
+func (r *recordingFS) files(dir string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files := make([]string, 0, len(r.written))
+	for name := range r.written {
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			rel = name
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,193 @@
+package gofast_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mahibulhaque/gofast/pkg/gofast"
+)
+
+// failingRunner fails the test when a command is run
+type failingRunner struct{ t *testing.T }
+
+func (r failingRunner) Run(_ context.Context, name string, args []string, _ string) error {
+	r.t.Errorf("ran %s %s with SkipCommands", name, strings.Join(args, " "))
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    gofast.Options
+		path    string
+		files   []string
+		missing []string
+		notes   []string
+	}{
+		{
+			name:    "defaults",
+			opts:    gofast.Options{Name: "github.com/acme/api"},
+			path:    "api",
+			files:   []string{"Makefile", "cmd/api/main.go", "go.mod", "internal/server/routes.go"},
+			missing: []string{"Dockerfile", "internal/db/database.go"},
+		},
+		{
+			name: "fiber with sqlite in docker",
+			opts: gofast.Options{
+				Name:      "github.com/acme/api",
+				Framework: gofast.Fiber,
+				DBDriver:  gofast.Sqlite,
+				Features:  []gofast.Feature{gofast.FeatureDocker, gofast.FeatureWebsocket},
+			},
+			path:  "api",
+			files: []string{"Dockerfile", "docker-compose.yml", "internal/db/database.go", "internal/server/routes.go"},
+			notes: []string{"Fiber is not based on net/http", "go-sqlite3 uses cgo"},
+		},
+		{
+			name: "chi with postgres in a directory",
+			opts: gofast.Options{
+				Name:      "github.com/acme/shop",
+				Framework: gofast.Chi,
+				DBDriver:  gofast.Postgres,
+				Features:  []gofast.Feature{gofast.FeatureGitHubAction},
+				Dir:       "projects",
+			},
+			path:  "projects/shop",
+			files: []string{".github/workflows/go-test.yml", "cmd/api/main.go", "internal/db/database.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := gofast.NewMemFS()
+			tt.opts.FS = fsys
+			tt.opts.Runner = failingRunner{t}
+			tt.opts.SkipCommands = true
+
+			result, err := gofast.Generate(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Path != tt.path {
+				t.Errorf("path = %q, want %q", result.Path, tt.path)
+			}
+			if !slices.IsSorted(result.Files) {
+				t.Errorf("files are not sorted: %v", result.Files)
+			}
+			for _, file := range tt.files {
+				if !slices.Contains(result.Files, file) {
+					t.Errorf("files lack %s: %v", file, result.Files)
+				}
+			}
+			for _, file := range tt.missing {
+				if slices.Contains(result.Files, file) {
+					t.Errorf("files hold %s: %v", file, result.Files)
+				}
+			}
+			for _, file := range result.Files {
+				if _, err := fsys.ReadFile(result.Path + "/" + file); err != nil {
+					t.Errorf("listed file was not written: %v", err)
+				}
+			}
+
+			if len(result.Notes) != len(tt.notes) {
+				t.Fatalf("notes = %q, want %d", result.Notes, len(tt.notes))
+			}
+			for i, note := range tt.notes {
+				if !strings.HasPrefix(result.Notes[i], note) {
+					t.Errorf("note %d = %q, want it to start with %q", i, result.Notes[i], note)
+				}
+			}
+
+			mod, err := fsys.ReadFile(result.Path + "/go.mod")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "module " + tt.opts.Name + "\n"; !strings.HasPrefix(string(mod), want) {
+				t.Errorf("go.mod starts with %q, want %q", strings.SplitN(string(mod), "\n", 2)[0], want)
+			}
+		})
+	}
+}
+
+func TestGenerateUnavailableFeature(t *testing.T) {
+	_, err := gofast.Generate(context.Background(), gofast.Options{
+		Name:         "github.com/acme/tool",
+		Framework:    gofast.Cli,
+		Features:     []gofast.Feature{gofast.FeatureDocker},
+		FS:           gofast.NewMemFS(),
+		SkipCommands: true,
+	})
+	if _, ok := err.(*gofast.ResolveError); !ok {
+		t.Errorf("err = %v, want a *ResolveError", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts gofast.Options
+		// err is part of the expected error, empty when the options are valid
+		err string
+	}{
+		{
+			name: "valid",
+			opts: gofast.Options{Name: "github.com/acme/api", Framework: gofast.Gin, DBDriver: gofast.Mongo, Git: gofast.GitCommit, PackageManager: gofast.Pnpm, Vars: map[string]string{"port": "3000"}},
+		},
+		{
+			name: "name",
+			opts: gofast.Options{Name: "github.com/acme/my api"},
+			err:  "'github.com/acme/my api' is not a valid module name",
+		},
+		{
+			name: "framework",
+			opts: gofast.Options{Name: "api", Framework: "rails"},
+			err:  "Framework to use",
+		},
+		{
+			name: "driver",
+			opts: gofast.Options{Name: "api", DBDriver: "oracle"},
+			err:  "Database to use",
+		},
+		{
+			name: "feature",
+			opts: gofast.Options{Name: "api", Features: []gofast.Feature{gofast.FeatureDocker, "kubernetes"}},
+			err:  "advanced Feature to use",
+		},
+		{
+			name: "git",
+			opts: gofast.Options{Name: "api", Git: "push"},
+			err:  "Git to use",
+		},
+		{
+			name: "package manager",
+			opts: gofast.Options{Name: "api", PackageManager: "deno"},
+			err:  "Package manager to use",
+		},
+		{
+			name: "var",
+			opts: gofast.Options{Name: "api", Vars: map[string]string{"port": "http"}},
+			err:  "port",
+		},
+		{
+			name: "unknown var",
+			opts: gofast.Options{Name: "api", Vars: map[string]string{"colour": "blue"}},
+			err:  "colour",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("err = %v, want nil", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("err = %v, want it to contain %q", err, tt.err)
+			}
+		})
+	}
+}